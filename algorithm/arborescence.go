@@ -0,0 +1,164 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "errors"
+    "fmt"
+)
+
+// an edge of an arborescence, which leads from the parent to the child
+// Edges of undirected graphs may be used against the direction they were created with.
+type ArborescenceArc struct {
+    Parent graphLib.VertexInterface
+    Child  graphLib.VertexInterface
+    Edge   graphLib.EdgeInterface
+}
+
+// simple wrapper
+func (this Graph) MinimumArborescence(root graphLib.VertexInterface) (float64, []ArborescenceArc, error) {
+    return MinimumArborescence(this, root)
+}
+
+// returns the weight and the arcs of the minimum spanning arborescence rooted at the root (Chu-Liu/Edmonds)
+// Every vertex except the root gets exactly one incoming arc and is reachable from the root. Edges of
+// undirected graphs can be used in both directions. Fails if a vertex is not reachable from the root.
+func MinimumArborescence(graph Graph, root graphLib.VertexInterface) (float64, []ArborescenceArc, error) {
+
+    // every vertex has to be reachable
+    num := int(graph.GetVertices().Count())
+    if reached := BreadthFirstSearch(graph, root); len(reached) < num {
+        discovered := make(map[graphLib.VertexInterface]bool, len(reached))
+        for _, v := range reached {
+            discovered[v] = true
+        }
+        for _, v := range graph.GetVertices().All() {
+            if !discovered[v] {
+                return 0, nil, errors.New(fmt.Sprintf("Vertex %d is not reachable from the root.", v.GetId()))
+            }
+        }
+    }
+
+    // the edges by vertex positions, self-loops never belong to an arborescence
+    all := graph.GetEdges().All()
+    edges := make([]arborescenceEdge, 0, 2 * len(all))
+    for i, edge := range all {
+        s, t := edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos()
+        if s == t {
+            continue
+        }
+        edges = append(edges, arborescenceEdge{s, t, edge.GetWeight(), i})
+        if !graph.IsDirected() {
+            edges = append(edges, arborescenceEdge{t, s, edge.GetWeight(), i})
+        }
+    }
+
+    // the chosen edges refer to the edges of the graph
+    vertices := graph.GetVertices()
+    weight, result := 0.0, make([]ArborescenceArc, 0, num)
+    for _, e := range chuLiuEdmonds(num, root.GetPos(), edges) {
+        weight += edges[e].weight
+        result = append(result, ArborescenceArc{vertices.GetPos(edges[e].from), vertices.GetPos(edges[e].to), all[edges[e].parent]})
+    }
+    return weight, result, nil
+}
+
+// an edge between vertex positions of the current level of contraction
+// The parent is the index of the edge it was created from on the level above.
+type arborescenceEdge struct {
+    from, to int
+    weight   float64
+    parent   int
+}
+
+// returns the indexes of the edges of the minimum arborescence
+// Every vertex has to be reachable from the root. Circles of cheapest incoming edges are contracted
+// to single vertices recursively, then they are expanded again. Each level copies the edges and there are
+// at most V levels, so it takes O(VE) time instead of the O(E log V) of Tarjan's version with mergeable heaps.
+func chuLiuEdmonds(num, root int, edges []arborescenceEdge) []int {
+
+    /*
+     * 1. Pick the cheapest incoming edge of each vertex.
+     */
+    in := make([]int, num)
+    for v := range in {
+        in[v] = -1
+    }
+    for i, e := range edges {
+        if e.to != root && e.from != e.to && (in[e.to] < 0 || e.weight < edges[in[e.to]].weight) {
+            in[e.to] = i
+        }
+    }
+
+    /*
+     * 2. Find the circles of these edges. Each vertex gets the number of its vertex in the contracted graph.
+     */
+    component, visited := make([]int, num), make([]int, num)
+    for v := range component {
+        component[v], visited[v] = -1, -1
+    }
+    circles := 0
+    for v := 0; v < num; v++ {
+
+        // follow the incoming edges until the root, a known vertex or a vertex of this walk is reached
+        w := v
+        for w != root && visited[w] < 0 && component[w] < 0 {
+            visited[w] = v
+            w = edges[in[w]].from
+        }
+        if w != root && visited[w] == v && component[w] < 0 {
+            for u := w; component[u] < 0; u = edges[in[u]].from {
+                component[u] = circles
+            }
+            circles++
+        }
+    }
+    if circles == 0 {
+        result := make([]int, 0, num - 1)
+        for v, e := range in {
+            if v != root {
+                result = append(result, e)
+            }
+        }
+        return result
+    }
+    contracted := circles
+    for v := range component {
+        if component[v] < 0 {
+            component[v] = contracted
+            contracted++
+        }
+    }
+
+    /*
+     * 3. Contract the circles. An edge into a circle gets cheaper by the incoming edge it replaces.
+     */
+    next := make([]arborescenceEdge, 0, len(edges))
+    for i, e := range edges {
+        from, to := component[e.from], component[e.to]
+        if from == to {
+            continue
+        }
+        weight := e.weight
+        if to < circles {
+            weight -= edges[in[e.to]].weight
+        }
+        next = append(next, arborescenceEdge{from, to, weight, i})
+    }
+
+    /*
+     * 4. Solve the contracted graph and expand the circles. Each circle is entered by one chosen edge,
+     *    it replaces the incoming edge of the vertex it enters.
+     */
+    chosen := chuLiuEdmonds(contracted, component[root], next)
+    result, entered := make([]int, 0, num - 1), make([]bool, num)
+    for _, e := range chosen {
+        result = append(result, next[e].parent)
+        entered[edges[next[e].parent].to] = true
+    }
+    for v := range component {
+        if component[v] < circles && !entered[v] {
+            result = append(result, in[v])
+        }
+    }
+    return result
+}
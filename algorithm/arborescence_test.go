@@ -0,0 +1,143 @@
+package algorithm
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+)
+
+// returns the weight of the minimum arborescence by trying every incoming edge of every vertex
+func bruteForceArborescence(num, root int, weights [][]float64) float64 {
+    in, best := make([]int, num), math.Inf(1)
+    var try func(v int, weight float64)
+    try = func(v int, weight float64) {
+        if v == num {
+            // every vertex has to lead to the root
+            for u := 0; u < num; u++ {
+                w := u
+                for steps := 0; w != root && steps < num; steps++ {
+                    w = in[w]
+                }
+                if w != root {
+                    return
+                }
+            }
+            best = math.Min(best, weight)
+            return
+        }
+        if v == root {
+            try(v + 1, weight)
+            return
+        }
+        for u := 0; u < num; u++ {
+            if !math.IsInf(weights[u][v], 1) && u != v {
+                in[v] = u
+                try(v + 1, weight + weights[u][v])
+            }
+        }
+    }
+    try(0, 0)
+    return best
+}
+
+// test the minimum arborescence against brute force on random directed graphs
+func TestMinimumArborescence(t *testing.T) {
+    r := rand.New(rand.NewSource(1))
+    for n := 0; n < 200; n++ {
+        num := 2 + r.Intn(5)
+        input := fmt.Sprintln(num)
+        for i := 0; i < num * 3; i++ {
+            input += fmt.Sprintln(r.Intn(num), r.Intn(num), r.Intn(10))
+        }
+        g, err := parser.ParseEdges(strings.NewReader(input), true)
+        if err != nil {
+            panic(err)
+        }
+        graph := Graph{g}
+        root := graph.GetVertices().Get(0)
+        expected := bruteForceArborescence(num, 0, graph.getDistanceMatrix())
+
+        weight, arcs, err := graph.MinimumArborescence(root)
+        if math.IsInf(expected, 1) {
+            if err == nil {
+                t.Errorf("Expected error for\n%s", input)
+            }
+            continue
+        }
+        if err != nil {
+            t.Fatal(err)
+        }
+        if weight != expected {
+            t.Errorf("Expected weight %f, got %f for\n%s", expected, weight, input)
+        }
+        validateArborescence(t, graph, root, weight, arcs)
+    }
+}
+
+// test the minimum arborescence on undirected graphs, where it is a minimal spanning tree pointing away from the root
+func TestMinimumArborescenceUndirected(t *testing.T) {
+
+    // both edges point towards the root
+    g, err := parser.ParseEdges(strings.NewReader("3\n1 0 5\n2 1 3\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    _, arcs, err := graph.MinimumArborescence(graph.GetVertices().Get(0))
+    if err != nil {
+        t.Fatal(err)
+    }
+    for i, expected := range [][2]uint{{0, 1}, {1, 2}} {
+        if p, c := uint(arcs[i].Parent.GetId()), uint(arcs[i].Child.GetId()); p != expected[0] || c != expected[1] {
+            t.Errorf("Expected arc %d -> %d, got %d -> %d.", expected[0], expected[1], p, c)
+        }
+    }
+
+    r := rand.New(rand.NewSource(2))
+    for n := 0; n < 100; n++ {
+        num := 2 + r.Intn(5)
+        graph, _ := randomConnectedGraph(r, num, r.Intn(6))
+        root := graph.GetVertices().Get(uint(r.Intn(num)))
+        expected := bruteForceArborescence(num, root.GetPos(), graph.getWeightMatrix())
+
+        weight, arcs, err := graph.MinimumArborescence(root)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if weight != expected {
+            t.Errorf("Expected weight %f, got %f.", expected, weight)
+        }
+        validateArborescence(t, graph, root, weight, arcs)
+    }
+}
+
+// checks that every vertex except the root is the child of exactly one arc and that the arcs belong to their edges
+func validateArborescence(t *testing.T, graph Graph, root graphLib.VertexInterface, weight float64, arcs []ArborescenceArc) {
+    num := int(graph.GetVertices().Count())
+    in, sum := make([]int, num), 0.0
+    for _, arc := range arcs {
+        in[arc.Child.GetPos()]++
+        sum += arc.Edge.GetWeight()
+        s, e := arc.Edge.GetStartVertex(), arc.Edge.GetEndVertex()
+        if !(s == arc.Parent && e == arc.Child || !graph.IsDirected() && s == arc.Child && e == arc.Parent) {
+            t.Errorf("Expected the edge of arc %d -> %d to connect them, got %d -> %d.", arc.Parent.GetId(), arc.Child.GetId(), s.GetId(), e.GetId())
+        }
+    }
+    for v, count := range in {
+        expect := 1
+        if v == root.GetPos() {
+            expect = 0
+        }
+        if count != expect {
+            t.Errorf("Expected vertex %d to have %d incoming arcs, got %d.", v, expect, count)
+        }
+    }
+    if sum != weight {
+        t.Errorf("Expected the arcs to weigh %f, got %f.", weight, sum)
+    }
+}
@@ -0,0 +1,170 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+)
+
+// simple wrapper
+func (this Graph) AssignmentPatchingHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return AssignmentPatchingHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle calculated by Karp's patching heuristic and its length
+// The assignment relaxation gives every vertex one successor, which results in disjoint circles.
+// These are merged by exchanging the successors of two vertices at minimal extra cost. It is meant
+// for directed graphs (asymmetric instances), undirected graphs are solved as symmetric ones.
+// The circle starts with the start vertex.
+func AssignmentPatchingHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    weights := graph.getDistanceMatrix()
+    tour := assignmentPatchingTour(weights, start.GetPos())
+    return graph.tourVertices(tour), tourLength(weights, tour)
+}
+
+// returns the patched circle as vertex positions
+func assignmentPatchingTour(weights [][]float64, start int) []int {
+    num := len(weights)
+    if num < 2 {
+        return []int{start}
+    }
+
+    /*
+     * 1. Replace missing edges by an expensive one, so that the assignment always exists.
+     */
+    big := 1.0
+    for i := range weights {
+        for j, w := range weights[i] {
+            if i != j && !math.IsInf(w, 1) {
+                big += math.Abs(w)
+            }
+        }
+    }
+    cost := make([][]float64, num)
+    for i := range cost {
+        cost[i] = make([]float64, num)
+        for j, w := range weights[i] {
+            if i == j || math.IsInf(w, 1) {
+                w = big
+            }
+            cost[i][j] = w
+        }
+    }
+
+    /*
+     * 2. Solve the assignment problem and find its circles.
+     */
+    succ := minCostAssignment(cost)
+    circle, sizes := make([]int, num), make([]int, 0, num)
+    for i := range circle {
+        circle[i] = -1
+    }
+    for v := 0; v < num; v++ {
+        if circle[v] < 0 {
+            sizes = append(sizes, 0)
+            for w := v; circle[w] < 0; w = succ[w] {
+                circle[w] = len(sizes) - 1
+                sizes[len(sizes) - 1]++
+            }
+        }
+    }
+
+    /*
+     * 3. Patch the other circles into the largest one, the cheapest exchange first.
+     */
+    largest := 0
+    for c, size := range sizes {
+        if size > sizes[largest] {
+            largest = c
+        }
+    }
+    for merged := 1; merged < len(sizes); merged++ {
+
+        // a -> succ[a] and b -> succ[b] become a -> succ[b] and b -> succ[a]
+        bestA, bestB, best := -1, -1, math.Inf(1)
+        for a := 0; a < num; a++ {
+            if circle[a] != largest {
+                continue
+            }
+            for b := 0; b < num; b++ {
+                if circle[b] == largest {
+                    continue
+                }
+                if delta := cost[a][succ[b]] + cost[b][succ[a]] - cost[a][succ[a]] - cost[b][succ[b]]; delta < best {
+                    bestA, bestB, best = a, b, delta
+                }
+            }
+        }
+        old := circle[bestB]
+        for v := range circle {
+            if circle[v] == old {
+                circle[v] = largest
+            }
+        }
+        succ[bestA], succ[bestB] = succ[bestB], succ[bestA]
+    }
+
+    /*
+     * 4. Follow the successors.
+     */
+    tour := make([]int, 1, num)
+    tour[0] = start
+    for v := succ[start]; v != start; v = succ[v] {
+        tour = append(tour, v)
+    }
+    return tour
+}
+
+// returns for each row the column of an assignment with minimal cost (Hungarian algorithm)
+// The potentials u and v of rows and columns are kept one-based, row and column 0 are helpers.
+func minCostAssignment(cost [][]float64) []int {
+    num := len(cost)
+    u, v := make([]float64, num + 1), make([]float64, num + 1)
+    row, way := make([]int, num + 1), make([]int, num + 1)
+
+    for i := 1; i <= num; i++ {
+
+        // add row i by finding an augmenting path starting at the helper column
+        row[0] = i
+        minimum, used := make([]float64, num + 1), make([]bool, num + 1)
+        for j := range minimum {
+            minimum[j] = math.Inf(1)
+        }
+        j0 := 0
+        for row[j0] != 0 {
+            used[j0] = true
+            i0, delta, j1 := row[j0], math.Inf(1), 0
+            for j := 1; j <= num; j++ {
+                if !used[j] {
+                    if c := cost[i0 - 1][j - 1] - u[i0] - v[j]; c < minimum[j] {
+                        minimum[j], way[j] = c, j0
+                    }
+                    if minimum[j] < delta {
+                        delta, j1 = minimum[j], j
+                    }
+                }
+            }
+            for j := 0; j <= num; j++ {
+                if used[j] {
+                    u[row[j]] += delta
+                    v[j] -= delta
+                } else {
+                    minimum[j] -= delta
+                }
+            }
+            j0 = j1
+        }
+
+        // augment along the path
+        for j0 != 0 {
+            j1 := way[j0]
+            row[j0] = row[j1]
+            j0 = j1
+        }
+    }
+
+    result := make([]int, num)
+    for j := 1; j <= num; j++ {
+        result[row[j] - 1] = j - 1
+    }
+    return result
+}
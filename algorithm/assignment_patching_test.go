@@ -0,0 +1,185 @@
+package algorithm
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+)
+
+// test the Hungarian algorithm against all permutations
+func TestMinCostAssignment(t *testing.T) {
+
+    // brute force the optimum
+    var bruteForce func(cost [][]float64, perm []int, k int) float64
+    bruteForce = func(cost [][]float64, perm []int, k int) float64 {
+        if k == len(perm) {
+            sum := 0.0
+            for i, j := range perm {
+                sum += cost[i][j]
+            }
+            return sum
+        }
+        best := math.Inf(1)
+        for i := k; i < len(perm); i++ {
+            perm[k], perm[i] = perm[i], perm[k]
+            best = math.Min(best, bruteForce(cost, perm, k + 1))
+            perm[k], perm[i] = perm[i], perm[k]
+        }
+        return best
+    }
+
+    r := rand.New(rand.NewSource(42))
+    for n := 1; n <= 7; n++ {
+        for round := 0; round < 20; round++ {
+            cost := make([][]float64, n)
+            for i := range cost {
+                cost[i] = make([]float64, n)
+                for j := range cost[i] {
+                    cost[i][j] = float64(r.Intn(50))
+                }
+            }
+            perm := make([]int, n)
+            for i := range perm {
+                perm[i] = i
+            }
+
+            assignment, used, sum := minCostAssignment(cost), make([]bool, n), 0.0
+            for i, j := range assignment {
+                if used[j] {
+                    t.Errorf("Column %d is assigned twice.", j)
+                }
+                used[j] = true
+                sum += cost[i][j]
+            }
+            if expect := bruteForce(cost, perm, 0); sum != expect {
+                t.Errorf("Expected assignment cost %f, got %f.", expect, sum)
+            }
+        }
+    }
+}
+
+// test the exact algorithms and the heuristics on random directed graphs
+func TestAsymmetricTravelingSalesman(t *testing.T) {
+    r := rand.New(rand.NewSource(42))
+    for round := 0; round < 10; round++ {
+        n := 5 + r.Intn(4)
+        input := fmt.Sprintf("%d\n", n)
+        for i := 0; i < n; i++ {
+            for j := 0; j < n; j++ {
+                if i != j {
+                    input += fmt.Sprintf("%d %d %d\n", i, j, 1 + r.Intn(50))
+                }
+            }
+        }
+        g, err := parser.ParseEdges(strings.NewReader(input), true)
+        if err != nil {
+            panic(err)
+        }
+        graph := Graph{g}
+        start := graph.GetVertices().Get(0)
+        weights := graph.getDistanceMatrix()
+
+        _, optimum, err := graph.TravelingSalesmanHeldKarp(1 << 30)
+        if err != nil {
+            panic(err)
+        }
+
+        // the exact algorithms
+        for _, branchAndBound := range []bool{false, true} {
+            tour, length, err := graph.TravelingSalesmanBruteForce(branchAndBound, 2)
+            if err != nil {
+                t.Errorf("Expected no error, got \"%s\".", err.Error())
+                continue
+            }
+            validateTour(t, graph, tour)
+            if length != optimum || tourLength(weights, tourPositions(tour)) != optimum {
+                t.Errorf("Expected length %f, got %f.", optimum, length)
+            }
+        }
+
+        // the heuristics
+        for name, heuristic := range map[string]func() ([]graphLib.VertexInterface, float64){
+            "nearest neighbour": func() ([]graphLib.VertexInterface, float64) { return graph.NearestNeighbourHamiltonCircle(start) },
+            "patching": func() ([]graphLib.VertexInterface, float64) { return graph.AssignmentPatchingHamiltonCircle(start) },
+        } {
+            tour, length := heuristic()
+            validateTour(t, graph, tour)
+            if tour[0] != start {
+                t.Errorf("Expected %s tour to start with vertex %d, got %d.", name, start.GetId(), tour[0].GetId())
+            }
+            if l := tourLength(weights, tourPositions(tour)); l != length || length < optimum {
+                t.Errorf("Expected %s length of at least %f, got %f (%f).", name, optimum, length, l)
+            }
+        }
+    }
+}
+
+// test that the solvers which need symmetric distances refuse asymmetric ones and work on symmetric directed graphs
+func TestAsymmetricTravelingSalesmanSymmetricSolvers(t *testing.T) {
+    r := rand.New(rand.NewSource(43))
+    for round := 0; round < 10; round++ {
+        n := 5 + r.Intn(4)
+        for _, symmetric := range []bool{false, true} {
+            input := fmt.Sprintf("%d\n", n)
+            for i := 0; i < n; i++ {
+                for j := i + 1; j < n; j++ {
+                    w := 1 + r.Intn(50)
+                    input += fmt.Sprintf("%d %d %d\n", i, j, w)
+                    if !symmetric {
+                        w = 1 + r.Intn(50)
+                    }
+                    input += fmt.Sprintf("%d %d %d\n", j, i, w)
+                }
+            }
+            g, err := parser.ParseEdges(strings.NewReader(input), true)
+            if err != nil {
+                panic(err)
+            }
+            graph := Graph{g}
+            start := graph.GetVertices().Get(0)
+
+            _, optimum, err := graph.TravelingSalesmanHeldKarp(1 << 30)
+            if err != nil {
+                panic(err)
+            }
+
+            // the lower bound of the symmetric relaxation is valid in both cases
+            if bound := graph.OneTreeLowerBound(); bound > optimum + 1e-6 {
+                t.Errorf("Expected 1-tree bound of at most %f, got %f.", optimum, bound)
+            }
+
+            solvers := map[string]func() ([]graphLib.VertexInterface, float64, error){
+                "Christofides": func() ([]graphLib.VertexInterface, float64, error) { return graph.ChristofidesHamiltonCircle(start) },
+                "Lin-Kernighan": func() ([]graphLib.VertexInterface, float64, error) { return graph.LinKernighanHamiltonCircle(start) },
+                "1-tree": graph.TravelingSalesmanOneTree,
+                "local search": func() ([]graphLib.VertexInterface, float64, error) { return graph.ImproveHamiltonCircle(graph.GetVertices().All()) },
+            }
+            for name, solver := range solvers {
+                tour, length, err := solver()
+                if !symmetric {
+                    if err == nil {
+                        t.Errorf("Expected %s to refuse asymmetric distances, got length %f.", name, length)
+                    }
+                    continue
+                }
+                if err != nil {
+                    t.Errorf("Expected no error of %s, got \"%s\".", name, err.Error())
+                    continue
+                }
+                validateTour(t, graph, tour)
+                if name == "1-tree" && math.Abs(length - optimum) > 1e-6 || length < optimum - 1e-6 {
+                    t.Errorf("Expected %s length of at least %f, got %f.", name, optimum, length)
+                }
+            }
+
+            _, _, err = graph.VehicleRoutingSavings(start, float64(n))
+            if symmetric != (err == nil) {
+                t.Errorf("Expected the savings algorithm to refuse only asymmetric distances, got error %v.", err)
+            }
+        }
+    }
+}
@@ -0,0 +1,167 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "errors"
+)
+
+// the cut vertices, bridges and biconnected components (blocks) of an undirected graph
+// The block-cut tree has one vertex for each block with its index as id, followed by one vertex for each articulation
+// point with the id len(Blocks) + its index. Each articulation point is connected to the blocks that contain it.
+// If the graph is not connected, the block-cut tree is a forest.
+type Biconnectivity struct {
+    ArticulationPoints []graphLib.VertexInterface
+    Bridges            []graphLib.EdgeInterface
+    Blocks             [][]graphLib.EdgeInterface
+    BlockCutTree       Graph
+}
+
+// simple wrapper
+func (this Graph) GetBiconnectivity() (*Biconnectivity, error) {
+    return GetBiconnectivity(this)
+}
+
+// returns the articulation points, bridges, blocks and the block-cut tree of the graph (Hopcroft and Tarjan)
+// An articulation point or a bridge disconnects its component when it is removed. A block is a maximal set of edges
+// where every two edges lie on a common circle, or a bridge. Loops do not belong to any block.
+func GetBiconnectivity(graph Graph) (*Biconnectivity, error) {
+    if graph.IsDirected() {
+        return nil, errors.New("Biconnected components need an undirected graph.")
+    }
+
+    // the neighbours and the edges that lead to them, by positions and indexes
+    num := int(graph.GetVertices().Count())
+    helper := biconnectivityHelper{
+        edges: graph.GetEdges().All(),
+        neighbours: make([][][2]int, num),
+        index: make([]int, num),
+        lowLink: make([]int, num),
+        cut: make([]bool, num),
+    }
+    for i, edge := range helper.edges {
+        a, b := edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos()
+        if a != b {
+            helper.neighbours[a] = append(helper.neighbours[a], [2]int{b, i})
+            helper.neighbours[b] = append(helper.neighbours[b], [2]int{a, i})
+        }
+    }
+
+    /*
+     * 1. Search each component, the root is an articulation point if it has more than one child.
+     */
+    for v := range helper.index {
+        helper.index[v] = -1
+    }
+    for v := 0; v < num; v++ {
+        if helper.index[v] < 0 {
+            helper.cut[v] = helper.search(v, -1) > 1
+        }
+    }
+
+    result := &Biconnectivity{Bridges: helper.bridges}
+    for _, block := range helper.blocks {
+        edges := make([]graphLib.EdgeInterface, len(block))
+        for j, i := range block {
+            edges[j] = helper.edges[i]
+        }
+        result.Blocks = append(result.Blocks, edges)
+    }
+
+    /*
+     * 2. Build the block-cut tree.
+     */
+    tree := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(false, uint(len(helper.blocks)), uint(len(helper.blocks)))
+    for b := range helper.blocks {
+        tree.NewVertexWithId(uint(b))
+    }
+    cutVertex := make(map[int]graphLib.VertexInterface)
+    for v, cut := range helper.cut {
+        if cut {
+            result.ArticulationPoints = append(result.ArticulationPoints, graph.GetVertices().GetPos(v))
+            cutVertex[v] = tree.NewVertexWithId(uint(len(helper.blocks) + len(cutVertex)))
+        }
+    }
+    for b, block := range helper.blocks {
+        connected := make(map[int]bool)
+        for _, i := range block {
+            for _, v := range [2]int{helper.edges[i].GetStartVertex().GetPos(), helper.edges[i].GetEndVertex().GetPos()} {
+                if helper.cut[v] && !connected[v] {
+                    connected[v] = true
+                    tree.NewEdge(tree.GetVertices().GetPos(b), cutVertex[v])
+                }
+            }
+        }
+    }
+    result.BlockCutTree = Graph{tree}
+
+    return result, nil
+}
+
+// helper for the search of the blocks, vertices are given by their positions and edges by their indexes
+type biconnectivityHelper struct {
+    edges      []graphLib.EdgeInterface
+    neighbours [][][2]int
+
+    // the order of discovery and the lowest one reachable through the search tree and one more edge
+    index      []int
+    lowLink    []int
+    next       int
+
+    // the edges that have no block yet
+    stack      []int
+
+    // the result
+    cut        []bool
+    bridges    []graphLib.EdgeInterface
+    blocks     [][]int
+}
+
+// visits all vertices reachable from v, which was reached by the given edge, and returns the number of its children
+func (this *biconnectivityHelper) search(v, parentEdge int) int {
+    this.index[v], this.lowLink[v] = this.next, this.next
+    this.next++
+
+    children := 0
+    for _, n := range this.neighbours[v] {
+        w, i := n[0], n[1]
+        if i == parentEdge {
+            // parallel edges to the parent are circles, so only this edge is skipped
+            continue
+        }
+        if this.index[w] < 0 {
+            children++
+            this.stack = append(this.stack, i)
+            this.search(w, i)
+            if this.lowLink[w] < this.lowLink[v] {
+                this.lowLink[v] = this.lowLink[w]
+            }
+
+            // nothing below w reaches above v, so v separates w from the rest
+            if this.lowLink[w] >= this.index[v] {
+                if parentEdge >= 0 {
+                    this.cut[v] = true
+                }
+                if this.lowLink[w] > this.index[v] {
+                    this.bridges = append(this.bridges, this.edges[i])
+                }
+                block := []int{}
+                for {
+                    e := this.stack[len(this.stack) - 1]
+                    this.stack = this.stack[:len(this.stack) - 1]
+                    block = append(block, e)
+                    if e == i {
+                        break
+                    }
+                }
+                this.blocks = append(this.blocks, block)
+            }
+        } else if this.index[w] < this.index[v] {
+            // an edge back to an ancestor, edges to descendants were already taken the other way
+            this.stack = append(this.stack, i)
+            if this.index[w] < this.lowLink[v] {
+                this.lowLink[v] = this.index[w]
+            }
+        }
+    }
+    return children
+}
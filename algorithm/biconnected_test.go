@@ -0,0 +1,142 @@
+package algorithm
+
+import (
+    "testing"
+    "math/rand"
+    "strings"
+    "fmt"
+    "github.com/teelevision/fhac-mmi/parser"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+)
+
+// returns the number of connected components without the given vertex and edge, -1 removes nothing
+func componentsWithout(num int, edges [][2]int, vertex, edge int) int {
+    components, count := newUnionFind(num), num
+    if vertex >= 0 {
+        count--
+    }
+    for i, e := range edges {
+        if i != edge && e[0] != vertex && e[1] != vertex && components.union(e[0], e[1]) {
+            count--
+        }
+    }
+    return count
+}
+
+// test the articulation points, bridges and blocks against removing vertices and edges
+func TestBiconnectivity(t *testing.T) {
+    r := rand.New(rand.NewSource(6))
+    for test := 0; test < 200; test++ {
+        num := 1 + r.Intn(9)
+        input, edges := fmt.Sprintln(num), [][2]int{}
+        for i := r.Intn(2 * num); i > 0; i-- {
+            e := [2]int{r.Intn(num), r.Intn(num)}
+            input += fmt.Sprintln(e[0], e[1])
+            edges = append(edges, e)
+        }
+        g, err := parser.ParseEdges(strings.NewReader(input), false)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+        index := make(map[graphLib.EdgeInterface]int)
+        for i, e := range graph.GetEdges().All() {
+            index[e] = i
+            edges[i] = [2]int{e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()}
+        }
+
+        result, err := graph.GetBiconnectivity()
+        if err != nil {
+            t.Fatal(err)
+        }
+        before := componentsWithout(num, edges, -1, -1)
+
+        // a vertex is an articulation point if removing it leaves more components
+        cut := make([]bool, num)
+        for _, v := range result.ArticulationPoints {
+            cut[v.GetPos()] = true
+        }
+        for v := 0; v < num; v++ {
+            if expected := componentsWithout(num, edges, v, -1) > before; expected != cut[v] {
+                t.Errorf("Expected %d to be an articulation point: %t, got %t.", v, expected, cut[v])
+            }
+        }
+
+        // an edge is a bridge if removing it leaves more components
+        bridge := make([]bool, len(edges))
+        for _, e := range result.Bridges {
+            bridge[index[e]] = true
+        }
+        for i := range edges {
+            if expected := componentsWithout(num, edges, -1, i) > before; expected != bridge[i] {
+                t.Errorf("Expected %v to be a bridge: %t, got %t.", edges[i], expected, bridge[i])
+            }
+        }
+
+        // every edge except loops belongs to exactly one block and blocks have no articulation points of their own
+        block, vertices := make([]int, len(edges)), make([]map[int]bool, len(result.Blocks))
+        for i := range block {
+            block[i] = -1
+        }
+        for b, edgesOfBlock := range result.Blocks {
+            vertices[b] = make(map[int]bool)
+            sub := [][2]int{}
+            for _, e := range edgesOfBlock {
+                if block[index[e]] >= 0 {
+                    t.Errorf("Expected edge %v in one block, got it twice.", edges[index[e]])
+                }
+                block[index[e]] = b
+                sub = append(sub, edges[index[e]])
+                vertices[b][edges[index[e]][0]], vertices[b][edges[index[e]][1]] = true, true
+            }
+            if len(vertices[b]) > 2 {
+                for v := range vertices[b] {
+                    if componentsWithout(num, sub, v, -1) != num - len(vertices[b]) + 1 {
+                        t.Errorf("Expected block %d to stay connected without %d.", b, v)
+                    }
+                }
+            }
+        }
+        for i, e := range edges {
+            if (e[0] == e[1]) != (block[i] < 0) {
+                t.Errorf("Expected edge %v in a block unless it is a loop.", e)
+            }
+        }
+
+        // blocks share at most one vertex, which is an articulation point
+        for a := range vertices {
+            for b := a + 1; b < len(vertices); b++ {
+                shared := 0
+                for v := range vertices[a] {
+                    if vertices[b][v] {
+                        shared++
+                        if !cut[v] {
+                            t.Errorf("Expected shared vertex %d to be an articulation point.", v)
+                        }
+                    }
+                }
+                if shared > 1 {
+                    t.Errorf("Expected blocks %d and %d to share at most one vertex, got %d.", a, b, shared)
+                }
+            }
+        }
+
+        // the block-cut tree is a forest
+        tree := result.BlockCutTree
+        n, m := int(tree.GetVertices().Count()), int(tree.GetEdges().Count())
+        if components, _ := tree.WeaklyConnectedComponents(); n != len(result.Blocks) + len(result.ArticulationPoints) || m != n - components {
+            t.Errorf("Expected a forest of %d vertices, got %d vertices, %d edges and %d components.", len(result.Blocks) + len(result.ArticulationPoints), n, m, components)
+        }
+    }
+
+    // directed graphs are not supported
+    g, err := parser.ParseEdges(strings.NewReader("2\n0 1\n"), false)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(true)
+    if _, err := (Graph{g}).GetBiconnectivity(); err == nil {
+        t.Errorf("Expected an error for a directed graph.")
+    }
+}
@@ -0,0 +1,152 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "runtime"
+    "sync"
+)
+
+// simple wrapper
+func (this Graph) Boruvka(start graphLib.VertexInterface) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface) {
+    return Boruvka(this, start)
+}
+
+// boruvka algorithm with result length, the minimal spanning tree and the mapping of the vertices to the ones of the tree
+// It is a MinimalSpanningTreeFunction, the start vertex is not needed. One goroutine per CPU is used.
+// If the graph is not connected, the result is a minimal spanning forest, see BoruvkaForest.
+func Boruvka(graph Graph, start graphLib.VertexInterface) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface) {
+    length, mst, vMap, _ := BoruvkaForest(graph, 0)
+    return length, mst, vMap
+}
+
+// simple wrapper
+func (this Graph) BoruvkaForest(workers int) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface, int) {
+    return BoruvkaForest(this, workers)
+}
+
+// returns the length of the minimal spanning forest, the forest, the mapping of the vertices to the ones of the
+// forest and the number of its components, which is 1 if the graph is connected
+// In each phase every component is connected by its cheapest edge. Finding these edges is split across the given
+// number of goroutines, or one per CPU if workers is not positive. The direction of the edges is ignored.
+func BoruvkaForest(graph Graph, workers int) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface, int) {
+    if workers <= 0 {
+        workers = runtime.NumCPU()
+    }
+
+    // the number of vertices and the edges
+    num := graph.GetVertices().Count()
+    edges := graph.GetEdges().All()
+
+    // map source to result vertex
+    vMap := make(map[graphLib.VertexInterface]graphLib.VertexInterface, num)
+
+    // the result spanning forest
+    result := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(false, num, num - 1)
+    for _, v := range graph.GetVertices().All() {
+        vMap[v] = result.NewVertex()
+    }
+
+    // the edges that may still connect two components, as indexes
+    active := make([]int, len(edges))
+    for i := range active {
+        active[i] = i
+    }
+
+    // an edge is cheaper if it is lighter or has the lower index when the weights are equal
+    // This order has no ties, so the cheapest edges of all components never close a circle.
+    cheaper := func(a, b int) bool {
+        if b < 0 {
+            return true
+        }
+        wa, wb := edges[a].GetWeight(), edges[b].GetWeight()
+        return wa < wb || wa == wb && a < b
+    }
+
+    // the components of the forest and the representative of each vertex in the current phase
+    components := newUnionFind(int(num))
+    label := make([]int, num)
+
+    // the cheapest edge of each component found by each worker
+    cheapest := make([][]int, workers)
+    for w := range cheapest {
+        cheapest[w] = make([]int, num)
+    }
+
+    // keep track of the length of the minimal spanning tree
+    length, n := 0.0, 0
+
+    for added := true; added && len(active) > 0; {
+        added = false
+        for v := range label {
+            label[v] = components.find(v)
+        }
+
+        /*
+         * 1. Each worker finds the cheapest edge of each component within its part of the edges and
+         *    drops the edges that are within a component.
+         */
+        kept := make([][]int, workers)
+        var wg sync.WaitGroup
+        wg.Add(workers)
+        for w := 0; w < workers; w++ {
+            go func(w int) {
+                defer wg.Done()
+                best := cheapest[w]
+                for c := range best {
+                    best[c] = -1
+                }
+                part := active[len(active) * w / workers:len(active) * (w + 1) / workers]
+                for _, e := range part {
+                    a, b := label[edges[e].GetStartVertex().GetPos()], label[edges[e].GetEndVertex().GetPos()]
+                    if a == b {
+                        continue
+                    }
+                    kept[w] = append(kept[w], e)
+                    if cheaper(e, best[a]) {
+                        best[a] = e
+                    }
+                    if cheaper(e, best[b]) {
+                        best[b] = e
+                    }
+                }
+            }(w)
+        }
+        wg.Wait()
+
+        /*
+         * 2. Merge the results of the workers.
+         */
+        best := cheapest[0]
+        for w := 1; w < workers; w++ {
+            for c, e := range cheapest[w] {
+                if e >= 0 && cheaper(e, best[c]) {
+                    best[c] = e
+                }
+            }
+        }
+        active = active[:0]
+        for _, part := range kept {
+            active = append(active, part...)
+        }
+
+        /*
+         * 3. Add the cheapest edge of each component. Two components may share it, so it is only added once.
+         */
+        for c, e := range best {
+            if e < 0 || label[c] != c {
+                continue
+            }
+            edge := edges[e]
+            start, end := edge.GetStartVertex(), edge.GetEndVertex()
+            if components.union(start.GetPos(), end.GetPos()) {
+                length += edge.GetWeight()
+                result.NewWeightedEdge(vMap[start], vMap[end], edge.GetWeight())
+                n++
+                added = true
+            }
+        }
+    }
+
+    // every edge of the forest merges two components
+    return length, Graph{result}, vMap, int(num) - n
+}
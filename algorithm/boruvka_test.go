@@ -0,0 +1,70 @@
+package algorithm
+
+import (
+    "testing"
+    "math"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the spanning tree of boruvka against the one of kruskal with different numbers of workers
+func TestBoruvka(t *testing.T) {
+
+    g, err := parser.ParseEdgesFile("test/G_100_200.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+    num := int(graph.GetVertices().Count())
+    expected := graph.KruskalLength()
+
+    for _, workers := range []int{1, 3, 0} {
+        length, mst, vMap, components := graph.BoruvkaForest(workers)
+        if math.Abs(length - expected) > 1e-6 {
+            t.Errorf("Expected length %f with %d workers, got %f.", expected, workers, length)
+        }
+        if n := int(mst.GetEdges().Count()); n != num - 1 || components != 1 {
+            t.Errorf("Expected %d edges and 1 component with %d workers, got %d and %d.", num - 1, workers, n, components)
+        }
+        if len(DepthFirstSearch(mst, vMap[graph.GetVertices().Get(0)])) != num {
+            t.Errorf("Expected a spanning tree that reaches all %d vertices.", num)
+        }
+    }
+}
+
+// test the spanning forest of a graph that is not connected and has equal weights
+func TestBoruvkaForest(t *testing.T) {
+
+    g, err := parser.ParseEdges(strings.NewReader("7\n0 1 1\n1 2 1\n0 2 1\n3 4 4\n4 5 5\n3 5 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    length, forest, _, components := Graph{g}.BoruvkaForest(2)
+    if length != 7 {
+        t.Errorf("Expected length 7, got %f.", length)
+    }
+    if n := forest.GetEdges().Count(); n != 4 {
+        t.Errorf("Expected 4 edges, got %d.", n)
+    }
+    if components != 3 {
+        t.Errorf("Expected 3 components, got %d.", components)
+    }
+}
+
+func BenchmarkBoruvka(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/G_100_200.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    for n := 0; n < b.N; n++ {
+        length, _, _ := graph.Boruvka(start)
+        if math.Abs(length - 27450.617104929264) > 1e-6 {
+            panic("Boruvka() result is wrong")
+        }
+    }
+
+}
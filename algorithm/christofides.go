@@ -0,0 +1,124 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "errors"
+    "fmt"
+)
+
+// simple wrapper
+func (this Graph) ChristofidesHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return ChristofidesHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle calculated by the Christofides algorithm and its length
+// On complete graphs that satisfy the triangle inequality the circle is at most 1.5 times as long as the shortest one.
+// Fails if the graph is not complete, its metric closure can be used instead. Directed graphs need the same
+// distances in both directions.
+func ChristofidesHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+
+    num := int(graph.GetVertices().Count())
+    weights, err := graph.getSymmetricDistanceMatrix("Christofides")
+    if err != nil {
+        return nil, 0, err
+    }
+    for s := range weights {
+        for t := range weights[s] {
+            if math.IsInf(weights[s][t], 1) {
+                return nil, 0, errors.New(fmt.Sprintf("The graph is not complete, vertices %d and %d are not connected. Use the metric closure.", graph.GetVertices().GetPos(s).GetId(), graph.GetVertices().GetPos(t).GetId()))
+            }
+        }
+    }
+
+    /*
+     * 1. Get the minimal spanning tree.
+     */
+    // the vertices of the tree have the same positions as the ones of the graph
+    _, mst, _ := Prim(graph, start)
+    edges := make([][2]int, 0, num + num / 2)
+    degree := make([]int, num)
+    for _, e := range mst.GetEdges().All() {
+        s, t := e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()
+        edges = append(edges, [2]int{s, t})
+        degree[s]++
+        degree[t]++
+    }
+
+    /*
+     * 2. Find the vertices with an odd degree.
+     */
+    odd := make([]int, 0, num)
+    for pos, d := range degree {
+        if d % 2 == 1 {
+            odd = append(odd, pos)
+        }
+    }
+
+    /*
+     * 3. Add a minimum weight perfect matching of those vertices.
+     */
+    for a, b := range minWeightPerfectMatching(weights, odd) {
+        if a < b {
+            edges = append(edges, [2]int{a, b})
+        }
+    }
+
+    /*
+     * 4. Every vertex has an even degree now, so there is an euler tour.
+     */
+    euler := eulerTour(num, edges, start.GetPos())
+
+    /*
+     * 5. Skip vertices that were already visited.
+     */
+    tour, visited := make([]int, 0, num), make([]bool, num)
+    for _, pos := range euler {
+        if !visited[pos] {
+            visited[pos] = true
+            tour = append(tour, pos)
+        }
+    }
+
+    return graph.tourVertices(tour), tourLength(weights, tour), nil
+}
+
+// returns an euler tour through the multigraph given by its edges using Hierholzer's algorithm
+// Every vertex must have an even degree. The tour starts and ends at the given position.
+func eulerTour(num int, edges [][2]int, start int) []int {
+
+    // the edges at each vertex
+    incident := make([][]int, num)
+    for k, e := range edges {
+        incident[e[0]] = append(incident[e[0]], k)
+        incident[e[1]] = append(incident[e[1]], k)
+    }
+
+    used, next := make([]bool, len(edges)), make([]int, num)
+    stack, tour := []int{start}, make([]int, 0, len(edges) + 1)
+    for len(stack) > 0 {
+        v := stack[len(stack) - 1]
+
+        // skip edges that were already used
+        for next[v] < len(incident[v]) && used[incident[v][next[v]]] {
+            next[v]++
+        }
+
+        if next[v] == len(incident[v]) {
+            // dead end: the vertex is finished
+            tour = append(tour, v)
+            stack = stack[:len(stack) - 1]
+        } else {
+            // follow the next unused edge
+            k := incident[v][next[v]]
+            used[k] = true
+            w := edges[k][0]
+            if w == v {
+                w = edges[k][1]
+            }
+            stack = append(stack, w)
+        }
+    }
+
+    return tour
+}
@@ -0,0 +1,142 @@
+package algorithm
+
+import (
+    "testing"
+    "github.com/teelevision/fhac-mmi/parser"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math/rand"
+    "strings"
+)
+
+// checks that the tour visits every vertex exactly once
+func validateTour(t *testing.T, graph Graph, tour []graphLib.VertexInterface) {
+    if n := len(tour); n != int(graph.GetVertices().Count()) {
+        t.Errorf("Expected tour to have %d vertices, got %d.", graph.GetVertices().Count(), n)
+    }
+    visited := map[int]bool{}
+    for _, v := range tour {
+        if visited[v.GetPos()] {
+            t.Errorf("Vertex %d is visited twice.", v.GetPos())
+        }
+        visited[v.GetPos()] = true
+    }
+}
+
+// test the Christofides algorithm against the optimal length
+func TestChristofidesHamiltonCircle(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_12.txt": 45.19} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+
+        tour, length, err := graph.ChristofidesHamiltonCircle(graph.GetVertices().Get(0))
+        if err != nil {
+            t.Fatal(err)
+        }
+        validateTour(t, graph, tour)
+        if length < optimum - 0.005 || length > 1.5 * optimum {
+            t.Errorf("Expected length between %f and %f for %s, got %f.", optimum, 1.5 * optimum, file, length)
+        }
+    }
+}
+
+// test that graphs which are not complete are refused
+func TestChristofidesHamiltonCircleNotComplete(t *testing.T) {
+
+    // a circle of 5 vertices with one chord
+    g, err := parser.ParseEdges(strings.NewReader("5\n0 1 1\n1 2 1\n2 3 1\n3 4 1\n4 0 1\n0 2 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    if _, _, err := graph.ChristofidesHamiltonCircle(graph.GetVertices().Get(0)); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+
+    // its metric closure is complete
+    closure, _, err := graph.MetricClosure()
+    if err != nil {
+        panic(err)
+    }
+    tour, _, err := closure.ChristofidesHamiltonCircle(closure.GetVertices().Get(0))
+    if err != nil {
+        t.Errorf("Expected no error on the metric closure, got \"%s\".", err.Error())
+    }
+    validateTour(t, closure, tour)
+}
+
+// test the minimum weight perfect matching against brute force
+func TestMinWeightPerfectMatching(t *testing.T) {
+
+    // brute force the optimum
+    var bruteForce func(weights [][]float64, vertices []int) float64
+    bruteForce = func(weights [][]float64, vertices []int) float64 {
+        if len(vertices) == 0 {
+            return 0
+        }
+        best := -1.0
+        for i := 1; i < len(vertices); i++ {
+            rest := append(append([]int{}, vertices[1:i]...), vertices[i + 1:]...)
+            if w := weights[vertices[0]][vertices[i]] + bruteForce(weights, rest); best < 0 || w < best {
+                best = w
+            }
+        }
+        return best
+    }
+
+    r := rand.New(rand.NewSource(42))
+    for n := 2; n <= 10; n += 2 {
+        for round := 0; round < 20; round++ {
+            weights := make([][]float64, n)
+            for i := range weights {
+                weights[i] = make([]float64, n)
+            }
+            for i := 0; i < n; i++ {
+                for j := i + 1; j < n; j++ {
+                    weights[i][j] = float64(r.Intn(100))
+                    weights[j][i] = weights[i][j]
+                }
+            }
+            vertices := make([]int, n)
+            for i := range vertices {
+                vertices[i] = i
+            }
+
+            matching := minWeightPerfectMatching(weights, vertices)
+            if len(matching) != n {
+                t.Errorf("Expected perfect matching of %d vertices, got %d.", n, len(matching))
+                continue
+            }
+            sum := 0.0
+            for a, b := range matching {
+                if matching[b] != a {
+                    t.Errorf("Matching is not symmetric: %d -> %d -> %d.", a, b, matching[b])
+                }
+                sum += weights[a][b] / 2
+            }
+            if expect := bruteForce(weights, vertices); sum != expect {
+                t.Errorf("Expected matching weight %f, got %f.", expect, sum)
+            }
+        }
+    }
+}
+
+func BenchmarkChristofidesHamiltonCircle(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/K_100.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    for n := 0; n < b.N; n++ {
+        graph.ChristofidesHamiltonCircle(start)
+    }
+
+}
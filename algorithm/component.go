@@ -10,6 +10,8 @@ func (this Graph) GetNumConnectedComponents() uint {
 }
 
 // calculates the number of connected components using a traverse function
+// On directed graphs the result depends on the order of the vertices, use StronglyConnectedComponents or
+// WeaklyConnectedComponents instead.
 func GetNumConnectedComponents(this Graph, tf TraverseFunction) uint {
 
     // 1. pick a vertex that was not visited yet
@@ -49,4 +51,152 @@ func GetNumConnectedComponents(this Graph, tf TraverseFunction) uint {
     }
 
     return numComponents
-}
\ No newline at end of file
+}
+// simple wrapper
+func (this Graph) StronglyConnectedComponents() (int, []int) {
+    return StronglyConnectedComponents(this)
+}
+
+// returns the number of strongly connected components and the component of each vertex by its position (Tarjan)
+// The components are numbered in a topological order of the condensation, so edges between different components
+// always lead to a higher number. In undirected graphs these are the connected components.
+func StronglyConnectedComponents(graph Graph) (int, []int) {
+    num := int(graph.GetVertices().Count())
+    helper := tarjanHelper{
+        graph: graph,
+        index: make([]int, num),
+        lowLink: make([]int, num),
+        onStack: make([]bool, num),
+        component: make([]int, num),
+    }
+    for v := range helper.index {
+        helper.index[v] = -1
+    }
+    for v := 0; v < num; v++ {
+        if helper.index[v] < 0 {
+            helper.search(v)
+        }
+    }
+
+    // tarjan finds the components in reverse topological order
+    for v, c := range helper.component {
+        helper.component[v] = helper.count - 1 - c
+    }
+    return helper.count, helper.component
+}
+
+// helper for tarjan's algorithm, vertices are given by their positions
+type tarjanHelper struct {
+    graph     Graph
+
+    // the order of discovery and the lowest one reachable through the search tree and one more edge
+    index     []int
+    lowLink   []int
+    next      int
+
+    // the vertices that have no component yet
+    stack     []int
+    onStack   []bool
+
+    // the result
+    component []int
+    count     int
+}
+
+// visits all vertices reachable from v and assigns the components that are completed
+func (this *tarjanHelper) search(v int) {
+    this.index[v], this.lowLink[v] = this.next, this.next
+    this.next++
+    this.stack = append(this.stack, v)
+    this.onStack[v] = true
+
+    for _, neighbour := range this.graph.getNeighboursOfVertex(this.graph.GetVertices().GetPos(v)).All() {
+        w := neighbour.GetPos()
+        if this.index[w] < 0 {
+            this.search(w)
+            if this.lowLink[w] < this.lowLink[v] {
+                this.lowLink[v] = this.lowLink[w]
+            }
+        } else if this.onStack[w] && this.index[w] < this.lowLink[v] {
+            this.lowLink[v] = this.index[w]
+        }
+    }
+
+    // v is the first vertex of its component, the rest of it is above v on the stack
+    if this.lowLink[v] == this.index[v] {
+        for {
+            w := this.stack[len(this.stack) - 1]
+            this.stack = this.stack[:len(this.stack) - 1]
+            this.onStack[w] = false
+            this.component[w] = this.count
+            if w == v {
+                break
+            }
+        }
+        this.count++
+    }
+}
+
+// simple wrapper
+func (this Graph) WeaklyConnectedComponents() (int, []int) {
+    return WeaklyConnectedComponents(this)
+}
+
+// returns the number of weakly connected components and the component of each vertex by its position
+// The direction of the edges is ignored. The components are numbered in the order of their first vertices.
+func WeaklyConnectedComponents(graph Graph) (int, []int) {
+    num := int(graph.GetVertices().Count())
+    components := newUnionFind(num)
+    for _, edge := range graph.GetEdges().All() {
+        components.union(edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos())
+    }
+
+    count, result, number := 0, make([]int, num), make(map[int]int)
+    for v := range result {
+        root := components.find(v)
+        if _, ok := number[root]; !ok {
+            number[root] = count
+            count++
+        }
+        result[v] = number[root]
+    }
+    return count, result
+}
+
+// simple wrapper
+func (this Graph) Condensation() (Graph, []int) {
+    return Condensation(this)
+}
+
+// returns the condensation of the graph and the component of each vertex by its position
+// Each strongly connected component becomes a vertex, whose id is the number of the component. Components are
+// connected by the lightest edge between them, so the result is a directed acyclic graph without parallel edges.
+func Condensation(graph Graph) (Graph, []int) {
+    count, component := StronglyConnectedComponents(graph)
+    result := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(true, uint(count), graph.GetEdges().Count())
+    for c := 0; c < count; c++ {
+        result.NewVertexWithId(uint(c))
+    }
+
+    // the lightest edge between each pair of components, in the order they are found
+    lightest, pairs := make(map[[2]int]float64), [][2]int{}
+    for _, vertex := range graph.GetVertices().All() {
+        for _, edge := range graph.getEdgesOfVertex(vertex).All() {
+            a, b := component[vertex.GetPos()], component[edge.GetOtherVertex(vertex).GetPos()]
+            if a == b {
+                continue
+            }
+            if w, ok := lightest[[2]int{a, b}]; !ok {
+                pairs = append(pairs, [2]int{a, b})
+                lightest[[2]int{a, b}] = edge.GetWeight()
+            } else if edge.GetWeight() < w {
+                lightest[[2]int{a, b}] = edge.GetWeight()
+            }
+        }
+    }
+    vertices := result.GetVertices()
+    for _, pair := range pairs {
+        result.NewWeightedEdge(vertices.GetPos(pair[0]), vertices.GetPos(pair[1]), lightest[pair])
+    }
+    return Graph{result}, component
+}
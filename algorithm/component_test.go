@@ -2,7 +2,11 @@ package algorithm
 
 import (
     "testing"
+    "math/rand"
+    "strings"
+    "fmt"
     "github.com/teelevision/fhac-mmi/graph"
+    "github.com/teelevision/fhac-mmi/parser"
 )
 
 // test the calculation of connected components
@@ -72,3 +76,103 @@ func TestConnectedComponents(t *testing.T) {
     test(1)
 
 }
+
+// returns which vertices can reach which by the edges, in both directions if the graph is undirected
+func reachability(num int, edges [][2]int, directed bool) [][]bool {
+    reach := make([][]bool, num)
+    for v := range reach {
+        reach[v] = make([]bool, num)
+        reach[v][v] = true
+    }
+    for _, e := range edges {
+        reach[e[0]][e[1]] = true
+        if !directed {
+            reach[e[1]][e[0]] = true
+        }
+    }
+    for k := 0; k < num; k++ {
+        for i := 0; i < num; i++ {
+            for j := 0; j < num; j++ {
+                reach[i][j] = reach[i][j] || reach[i][k] && reach[k][j]
+            }
+        }
+    }
+    return reach
+}
+
+// test the strongly and weakly connected components and the condensation against the reachability
+func TestStronglyConnectedComponents(t *testing.T) {
+    r := rand.New(rand.NewSource(5))
+    for test := 0; test < 200; test++ {
+        num := 1 + r.Intn(10)
+        input, edges := fmt.Sprintln(num), [][2]int{}
+        for i := r.Intn(2 * num); i > 0; i-- {
+            e := [2]int{r.Intn(num), r.Intn(num)}
+            input += fmt.Sprintln(e[0], e[1])
+            edges = append(edges, e)
+        }
+        g, err := parser.ParseEdges(strings.NewReader(input), false)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(true)
+        a := Graph{g}
+        position := make([]int, num)
+        for _, v := range a.GetVertices().All() {
+            position[v.GetId()] = v.GetPos()
+        }
+
+        // strongly connected vertices can reach each other
+        reach := reachability(num, edges, true)
+        count, component := a.StronglyConnectedComponents()
+        found := make(map[int]bool)
+        for u := 0; u < num; u++ {
+            found[component[position[u]]] = true
+            for v := 0; v < num; v++ {
+                same := component[position[u]] == component[position[v]]
+                if same != (reach[u][v] && reach[v][u]) {
+                    t.Errorf("Expected %d and %d to be in the same component: %t, got %t.", u, v, !same, same)
+                }
+            }
+        }
+        if len(found) != count {
+            t.Errorf("Expected %d strongly connected components, got %d.", len(found), count)
+        }
+
+        // the condensation follows the order of the components and has no parallel edges
+        condensation, _ := a.Condensation()
+        if n := int(condensation.GetVertices().Count()); n != count {
+            t.Errorf("Expected %d vertices of the condensation, got %d.", count, n)
+        }
+        pairs := make(map[[2]uint]bool)
+        for _, e := range condensation.GetEdges().All() {
+            pair := [2]uint{uint(e.GetStartVertex().GetId()), uint(e.GetEndVertex().GetId())}
+            if pair[0] >= pair[1] || pairs[pair] {
+                t.Errorf("Expected an acyclic condensation without parallel edges, got edge %d -> %d.", pair[0], pair[1])
+            }
+            pairs[pair] = true
+        }
+        for _, e := range edges {
+            ca, cb := component[position[e[0]]], component[position[e[1]]]
+            if ca != cb && !pairs[[2]uint{uint(ca), uint(cb)}] {
+                t.Errorf("Expected edge %d -> %d in the condensation.", ca, cb)
+            }
+        }
+
+        // weakly connected vertices are connected when the direction is ignored
+        reach = reachability(num, edges, false)
+        count, component = a.WeaklyConnectedComponents()
+        found = make(map[int]bool)
+        for u := 0; u < num; u++ {
+            found[component[position[u]]] = true
+            for v := 0; v < num; v++ {
+                if same := component[position[u]] == component[position[v]]; same != reach[u][v] {
+                    t.Errorf("Expected %d and %d to be in the same weak component: %t, got %t.", u, v, !same, same)
+                }
+            }
+        }
+        if len(found) != count {
+            t.Errorf("Expected %d weakly connected components, got %d.", len(found), count)
+        }
+    }
+}
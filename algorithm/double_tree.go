@@ -2,15 +2,18 @@ package algorithm
 
 import (
     graphLib "github.com/teelevision/fhac-mmi/graph"
+    "errors"
+    "fmt"
 )
 
 // simple wrapper
-func (this Graph) DoubleTreeHamiltonCircle(mst MinimalSpanningTreeFunction, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+func (this Graph) DoubleTreeHamiltonCircle(mst MinimalSpanningTreeFunction, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
     return DoubleTreeHamiltonCircle(this, mst, start)
 }
 
-// returns the length of the hamilton circle calculated by the double tree algorithm
-func DoubleTreeHamiltonCircle(graph Graph, mst MinimalSpanningTreeFunction, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+// returns the hamilton circle calculated by the double tree algorithm and its length
+// Fails if the graph is not complete, its metric closure can be used instead.
+func DoubleTreeHamiltonCircle(graph Graph, mst MinimalSpanningTreeFunction, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
 
     // get the minimal spanning tree
     _, mstGraph, vMap := mst(graph, start)
@@ -24,18 +27,21 @@ func DoubleTreeHamiltonCircle(graph Graph, mst MinimalSpanningTreeFunction, star
         mapping[b] = a
     }
 
-    // sum up the weight
+    // the tree only reaches the component of the start
+    if uint(len(result)) != graph.GetVertices().Count() {
+        return nil, 0, errors.New("The graph is not connected, so there is no hamilton circle.")
+    }
+
+    // sum up the weight, including the return to start
     length := 0.0
-    for i := len(result) - 1; i > 0; i-- {
-        if w := graph.getWeightBetween(mapping[result[i]], mapping[result[i - 1]]); w >= 0.0 {
+    for i := 0; len(result) > 1 && i < len(result); i++ {
+        a, b := mapping[result[i]], mapping[result[(i + 1) % len(result)]]
+        if w := graph.getWeightBetween(a, b); w >= 0.0 {
             length += w
         } else {
-            panic("weight not found")
+            return nil, 0, errors.New(fmt.Sprintf("The graph is not complete, vertices %d and %d are not connected. Use the metric closure.", a.GetId(), b.GetId()))
         }
     }
 
-    // return to start
-    length += graph.getWeightBetween(mapping[result[0]], mapping[result[len(result)-1]])
-
-    return result, length
+    return result, length, nil
 }
\ No newline at end of file
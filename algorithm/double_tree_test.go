@@ -2,6 +2,7 @@ package algorithm
 
 import (
     "testing"
+    "strings"
     "github.com/teelevision/fhac-mmi/parser"
 )
 
@@ -15,10 +16,47 @@ func BenchmarkDoubleTreeHamiltonCircleLength(b *testing.B) {
     start := graph.GetVertices().Get(0)
 
     for n := 0; n < b.N; n++ {
-        _, length := graph.DoubleTreeHamiltonCircle(Prim, start)
-        if length != 385.44999999999993 {
+        _, length, err := graph.DoubleTreeHamiltonCircle(Prim, start)
+        if err != nil || length != 385.44999999999993 {
             panic("DoubleTreeHamiltonCircleLength() result is wrong")
         }
     }
 
 }
+
+// test that graphs which are not complete are refused instead of summing up missing edges
+func TestDoubleTreeHamiltonCircleNotComplete(t *testing.T) {
+
+    // a circle of 5 vertices with one chord
+    g, err := parser.ParseEdges(strings.NewReader("5\n0 1 1\n1 2 1\n2 3 1\n3 4 1\n4 0 1\n0 2 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    if _, _, err := graph.DoubleTreeHamiltonCircle(Prim, graph.GetVertices().Get(0)); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+
+    // its metric closure is complete
+    closure, _, err := graph.MetricClosure()
+    if err != nil {
+        panic(err)
+    }
+    tour, _, err := closure.DoubleTreeHamiltonCircle(Prim, closure.GetVertices().Get(0))
+    if err != nil {
+        t.Errorf("Expected no error on the metric closure, got \"%s\".", err.Error())
+    }
+    validateTour(t, closure, tour)
+
+    // a graph that is not connected has no circle at all
+    g, err = parser.ParseEdges(strings.NewReader("4\n0 1 1\n2 3 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph = Graph{g}
+    if _, _, err := graph.DoubleTreeHamiltonCircle(Prim, graph.GetVertices().Get(0)); err == nil {
+        t.Error("Expected error for a graph that is not connected, got nil.")
+    }
+}
@@ -0,0 +1,294 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "math/rand"
+    "container/heap"
+    "errors"
+    "fmt"
+)
+
+// a vertex that is a point in the plane, as parsed by parser.ParseCoordinates
+type coordinateVertex interface {
+    GetCoordinates() (float64, float64)
+}
+
+// returns the points of the vertices indexed by their positions
+func (this Graph) getPoints() ([][2]float64, error) {
+    points := make([][2]float64, this.GetVertices().Count())
+    for _, v := range this.GetVertices().All() {
+        cv, ok := v.(coordinateVertex)
+        if !ok {
+            return nil, errors.New(fmt.Sprintf("Vertex %d has no coordinates.", v.GetId()))
+        }
+        points[v.GetPos()][0], points[v.GetPos()][1] = cv.GetCoordinates()
+    }
+    return points, nil
+}
+
+// returns the weight function of the euclidean distances between the points
+func euclideanWeight(points [][2]float64) weightFunction {
+    return func(a, b int) float64 {
+        return math.Hypot(points[a][0] - points[b][0], points[a][1] - points[b][1])
+    }
+}
+
+// simple wrapper
+func (this Graph) EuclideanNearestNeighbourHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return EuclideanNearestNeighbourHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle calculated by the nearest neighbour algorithm and its length
+// The edges of the graph are ignored, the weights are the euclidean distances between the coordinates of the vertices.
+// The nearest neighbours are found with a k-d tree, so no complete graph is needed.
+func EuclideanNearestNeighbourHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    points, err := graph.getPoints()
+    if err != nil {
+        return nil, 0, err
+    }
+
+    // the tree contains the vertices that are not visited yet
+    tree := newKdTree(points, true)
+    tree.setActive(start.GetPos(), false)
+    tour := make([]int, 1, len(points))
+    tour[0] = start.GetPos()
+    for v := tour[0]; len(tour) < len(points); {
+        v = tree.nearest(points[v])
+        tree.setActive(v, false)
+        tour = append(tour, v)
+    }
+
+    return graph.tourVertices(tour), euclideanTourLength(points, tour), nil
+}
+
+// simple wrapper
+func (this Graph) EuclideanNearestInsertionHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return EuclideanNearestInsertionHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle built by nearest insertion and its length
+// The weights are the euclidean distances between the coordinates of the vertices. The vertex nearest to the circle
+// is inserted next to one of the nearest vertices of the circle, where it adds the least length.
+func EuclideanNearestInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return euclideanInsertionHamiltonCircle(graph, start, insertionNearest, nil)
+}
+
+// simple wrapper
+func (this Graph) EuclideanRandomInsertionHamiltonCircle(start graphLib.VertexInterface, seed int64) ([]graphLib.VertexInterface, float64, error) {
+    return EuclideanRandomInsertionHamiltonCircle(this, start, seed)
+}
+
+// returns the hamilton circle built by random insertion and its length
+// The weights are the euclidean distances between the coordinates of the vertices. The vertices are inserted in
+// a random order given by the seed, each next to one of the nearest vertices of the circle, where it adds the least length.
+func EuclideanRandomInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface, seed int64) ([]graphLib.VertexInterface, float64, error) {
+    return euclideanInsertionHamiltonCircle(graph, start, insertionRandom, rand.New(rand.NewSource(seed)))
+}
+
+// helper for the euclidean insertion heuristics
+func euclideanInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface, strategy int, r *rand.Rand) ([]graphLib.VertexInterface, float64, error) {
+    points, err := graph.getPoints()
+    if err != nil {
+        return nil, 0, err
+    }
+    tour := euclideanInsertionTour(points, start.GetPos(), strategy, r)
+    return graph.tourVertices(tour), euclideanTourLength(points, tour), nil
+}
+
+// a vertex of the circle and the nearest vertex that is not in the circle yet
+type insertionCandidate struct {
+    distance      float64
+    circle, other int
+}
+
+// the candidates of nearest insertion with the nearest one first
+type insertionQueue []insertionCandidate
+
+func (this insertionQueue) Len() int {
+    return len(this)
+}
+
+func (this insertionQueue) Less(i, j int) bool {
+    return this[i].distance < this[j].distance
+}
+
+func (this insertionQueue) Swap(i, j int) {
+    this[i], this[j] = this[j], this[i]
+}
+
+func (this *insertionQueue) Push(x interface{}) {
+    *this = append(*this, x.(insertionCandidate))
+}
+
+func (this *insertionQueue) Pop() interface{} {
+    old := *this
+    item := old[len(old) - 1]
+    *this = old[:len(old) - 1]
+    return item
+}
+
+// returns the circle built by the insertion strategy as vertex positions, starting with the start vertex
+// Only nearest and random insertion are supported.
+func euclideanInsertionTour(points [][2]float64, start, strategy int, r *rand.Rand) []int {
+    num, weight := len(points), euclideanWeight(points)
+
+    // the circle is kept as the successor and predecessor of each vertex
+    next, prev := make([]int, num), make([]int, num)
+    next[start], prev[start] = start, start
+
+    // the trees of the vertices outside and inside the circle
+    outside, inside := newKdTree(points, true), newKdTree(points, false)
+    outside.setActive(start, false)
+    inside.setActive(start, true)
+
+    // the queue of nearest insertion holds the nearest outside vertex of each circle vertex
+    // An entry is outdated if its vertex was inserted meanwhile, it is updated when taken from the queue.
+    q := &insertionQueue{}
+    push := func(t int) {
+        if v := outside.nearest(points[t]); v >= 0 {
+            heap.Push(q, insertionCandidate{weight(t, v), t, v})
+        }
+    }
+    push(start)
+
+    // the order of random insertion
+    var order []int
+    if strategy == insertionRandom {
+        for _, v := range r.Perm(num) {
+            if v != start {
+                order = append(order, v)
+            }
+        }
+    }
+
+    for n := 1; n < num; n++ {
+
+        /*
+         * 1. Select the vertex to insert.
+         */
+        v, near := -1, -1
+        switch strategy {
+        case insertionRandom:
+            v = order[n - 1]
+        default:
+            for v < 0 {
+                if c := heap.Pop(q).(insertionCandidate); outside.active[c.other] {
+                    v, near = c.other, c.circle
+                } else {
+                    push(c.circle)
+                }
+            }
+        }
+
+        /*
+         * 2. Insert it next to one of the nearest vertices of the circle where it adds the least length.
+         */
+        a, best := start, math.Inf(1)
+        for _, c := range inside.kNearest(points[v], localSearchNeighbours, -1) {
+            for _, b := range [2]int{c, prev[c]} {
+                if cost := weight(b, v) + weight(v, next[b]) - weight(b, next[b]); cost < best {
+                    a, best = b, cost
+                }
+            }
+        }
+        next[v], prev[v] = next[a], a
+        prev[next[a]], next[a] = v, v
+
+        /*
+         * 3. Update the trees and the queue.
+         */
+        outside.setActive(v, false)
+        inside.setActive(v, true)
+        if strategy == insertionNearest {
+            push(v)
+            push(near)
+        }
+    }
+
+    tour := make([]int, 1, num)
+    tour[0] = start
+    for v := next[start]; v != start; v = next[v] {
+        tour = append(tour, v)
+    }
+    return tour
+}
+
+// simple wrapper
+func (this Graph) EuclideanImproveHamiltonCircle(tour []graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return EuclideanImproveHamiltonCircle(this, tour)
+}
+
+// improves the hamilton circle with 2-opt moves until no improving move is left
+// The weights are the euclidean distances between the coordinates of the vertices. The moves only connect
+// vertices to their nearest neighbours, which are found with a k-d tree. The result starts with the same vertex.
+func EuclideanImproveHamiltonCircle(graph Graph, tour []graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    points, err := graph.getPoints()
+    if err != nil {
+        return nil, 0, err
+    }
+    positions := tourPositions(tour)
+    if len(positions) >= 5 {
+        tree := newKdTree(points, true)
+        neighbours := make([][]int, len(points))
+        for v := range neighbours {
+            neighbours[v] = tree.kNearest(points[v], localSearchNeighbours, v)
+        }
+        t := newTourImprover(euclideanWeight(points), positions, neighbours)
+        t.runMoves(t.twoOpt)
+        positions = t.rotatedTo(positions[0])
+    }
+    return graph.tourVertices(positions), euclideanTourLength(points, positions), nil
+}
+
+// simple wrapper
+func (this Graph) EuclideanMinimalSpanningTreeLength() (float64, error) {
+    return EuclideanMinimalSpanningTreeLength(this)
+}
+
+// returns the length of the minimal spanning tree of the points, a lower bound of every hamilton circle
+// It is Prim's algorithm where each vertex of the tree knows its nearest vertex outside of the tree, found with
+// a k-d tree. These only get farther, so an outdated one is asked again when it is taken from the queue.
+func EuclideanMinimalSpanningTreeLength(graph Graph) (float64, error) {
+    points, err := graph.getPoints()
+    if err != nil {
+        return 0, err
+    }
+    if len(points) == 0 {
+        return 0, nil
+    }
+    weight := euclideanWeight(points)
+
+    // the tree contains the vertices outside of the spanning tree
+    outside := newKdTree(points, true)
+    q := &insertionQueue{}
+    add := func(v int) {
+        outside.setActive(v, false)
+        if w := outside.nearest(points[v]); w >= 0 {
+            heap.Push(q, insertionCandidate{weight(v, w), v, w})
+        }
+    }
+    add(0)
+
+    length := 0.0
+    for q.Len() > 0 {
+        c := heap.Pop(q).(insertionCandidate)
+        if outside.active[c.other] {
+            length += c.distance
+            add(c.other)
+        }
+        if w := outside.nearest(points[c.circle]); w >= 0 {
+            heap.Push(q, insertionCandidate{weight(c.circle, w), c.circle, w})
+        }
+    }
+    return length, nil
+}
+
+// returns the length of the circle with euclidean distances
+func euclideanTourLength(points [][2]float64, tour []int) float64 {
+    weight, length := euclideanWeight(points), 0.0
+    for i := range tour {
+        length += weight(tour[i], tour[(i + 1) % len(tour)])
+    }
+    return length
+}
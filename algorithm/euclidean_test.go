@@ -0,0 +1,126 @@
+package algorithm
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+    "strings"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// returns a graph of random points and the complete graph of the same points with euclidean weights
+func randomEuclideanGraphs(seed int64, num int) (Graph, Graph) {
+    r := rand.New(rand.NewSource(seed))
+    points, edges := fmt.Sprintln(num), fmt.Sprintln(num)
+    coordinates := make([][2]float64, num)
+    for i := range coordinates {
+        coordinates[i] = [2]float64{r.Float64() * 1000, r.Float64() * 1000}
+        points += fmt.Sprintln(coordinates[i][0], coordinates[i][1])
+        for j := 0; j < i; j++ {
+            edges += fmt.Sprintln(j, i, math.Hypot(coordinates[i][0] - coordinates[j][0], coordinates[i][1] - coordinates[j][1]))
+        }
+    }
+    g, err := parser.ParseCoordinates(strings.NewReader(points))
+    if err != nil {
+        panic(err)
+    }
+    complete, err := parser.ParseEdges(strings.NewReader(edges), true)
+    if err != nil {
+        panic(err)
+    }
+    complete.SetDirected(false)
+    return Graph{g}, Graph{complete}
+}
+
+// test the euclidean heuristics against the ones on the complete graph
+func TestEuclideanHamiltonCircle(t *testing.T) {
+    graph, complete := randomEuclideanGraphs(1, 200)
+    start := graph.GetVertices().Get(3)
+
+    // nearest neighbour finds the same circle
+    tour, length, err := graph.EuclideanNearestNeighbourHamiltonCircle(start)
+    if err != nil {
+        t.Fatal(err)
+    }
+    validateTour(t, graph, tour)
+    expected, expectedLength := complete.NearestNeighbourHamiltonCircle(complete.GetVertices().Get(3))
+    for i := range tour {
+        if tour[i].GetId() != expected[i].GetId() {
+            t.Fatalf("Expected the same nearest neighbour circle, got %d instead of %d at %d.", tour[i].GetId(), expected[i].GetId(), i)
+        }
+    }
+    if math.Abs(length - expectedLength) > 1e-6 {
+        t.Errorf("Expected length %f, got %f.", expectedLength, length)
+    }
+
+    // the insertion heuristics are near the ones of the complete graph
+    for name, heuristic := range map[string]func() ([]graphLib.VertexInterface, float64, error){
+        "nearest": func() ([]graphLib.VertexInterface, float64, error) { return graph.EuclideanNearestInsertionHamiltonCircle(start) },
+        "random": func() ([]graphLib.VertexInterface, float64, error) { return graph.EuclideanRandomInsertionHamiltonCircle(start, 42) },
+    } {
+        tour, length, err := heuristic()
+        if err != nil {
+            t.Fatal(err)
+        }
+        validateTour(t, graph, tour)
+        if tour[0] != start {
+            t.Errorf("Expected %s insertion to start with vertex %d, got %d.", name, start.GetId(), tour[0].GetId())
+        }
+        if length > 1.5 * expectedLength {
+            t.Errorf("Expected %s insertion to be shorter than %f, got %f.", name, 1.5 * expectedLength, length)
+        }
+
+        // 2-opt does not make it longer
+        improved, improvedLength, err := graph.EuclideanImproveHamiltonCircle(tour)
+        if err != nil {
+            t.Fatal(err)
+        }
+        validateTour(t, graph, improved)
+        if improved[0] != start || improvedLength > length + 1e-9 {
+            t.Errorf("Expected an improved %s insertion circle starting with %d shorter than %f, got %f.", name, start.GetId(), length, improvedLength)
+        }
+    }
+
+    // the complete graph has no coordinates
+    if _, _, err := complete.EuclideanNearestNeighbourHamiltonCircle(complete.GetVertices().Get(0)); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}
+
+// test the euclidean minimal spanning tree against prim on the complete graph
+func TestEuclideanMinimalSpanningTreeLength(t *testing.T) {
+    for seed := int64(1); seed <= 3; seed++ {
+        graph, complete := randomEuclideanGraphs(seed, 100)
+        length, err := graph.EuclideanMinimalSpanningTreeLength()
+        if err != nil {
+            t.Fatal(err)
+        }
+        if expected, _, _ := complete.Prim(complete.GetVertices().Get(0)); math.Abs(length - expected) > 1e-6 {
+            t.Errorf("Expected length %f, got %f.", expected, length)
+        }
+    }
+}
+
+func BenchmarkEuclideanHamiltonCircle(b *testing.B) {
+
+    r := rand.New(rand.NewSource(1))
+    lines := []string{fmt.Sprint(100000)}
+    for i := 0; i < 100000; i++ {
+        lines = append(lines, fmt.Sprint(r.Float64(), " ", r.Float64()))
+    }
+    g, err := parser.ParseCoordinates(strings.NewReader(strings.Join(lines, "\n")))
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        tour, _, _ := graph.EuclideanNearestNeighbourHamiltonCircle(start)
+        graph.EuclideanImproveHamiltonCircle(tour)
+        graph.EuclideanNearestInsertionHamiltonCircle(start)
+    }
+}
@@ -0,0 +1,111 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "errors"
+    "fmt"
+)
+
+// the maximum supported number of vertices in the Held-Karp algorithm
+const heldKarpMax = 32
+
+// simple wrapper
+func (this Graph) TravelingSalesmanHeldKarp(maxMemory uint64) ([]graphLib.VertexInterface, float64, error) {
+    return TravelingSalesmanHeldKarp(this, maxMemory)
+}
+
+// returns the shortest hamilton circle and its length using the Held-Karp dynamic programming algorithm
+// The memory needed grows with 2^n * n, so it is checked against maxMemory (in bytes) before starting.
+// About 25 vertices can be solved with 4 GiB. The circle starts with the first vertex.
+// Directed graphs are solved as asymmetric instances.
+func TravelingSalesmanHeldKarp(graph Graph, maxMemory uint64) ([]graphLib.VertexInterface, float64, error) {
+
+    // ----------------------------------------------
+    // check pre-requirements
+    // ----------------------------------------------
+
+    num := int(graph.GetVertices().Count())
+    if num < 2 || num > heldKarpMax {
+        return nil, 0, errors.New(fmt.Sprintf("Held-Karp only supports 2 to %d vertices, got %d.", heldKarpMax, num))
+    }
+    if memory := heldKarpMemory(num); memory > maxMemory {
+        return nil, 0, errors.New(fmt.Sprintf("Held-Karp needs %.1f MiB for %d vertices, but only %.1f MiB are allowed.", float64(memory) / (1 << 20), num, float64(maxMemory) / (1 << 20)))
+    }
+
+    // ----------------------------------------------
+    // dynamic programming
+    // ----------------------------------------------
+    // Vertex 0 is the start. The other vertices 1..n-1 are represented by the bits 0..n-2 of a set.
+    // length[set * m + j] is the length of the shortest path that starts at vertex 0,
+    // visits all vertices of the set and ends at vertex j + 1, which is part of the set.
+    // ----------------------------------------------
+
+    weights := graph.getDistanceMatrix()
+    m := num - 1
+    length := make([]float64, (1 << uint(m)) * m)
+
+    for set := 1; set < 1 << uint(m); set++ {
+        for j := 0; j < m; j++ {
+            if set & (1 << uint(j)) == 0 {
+                continue
+            }
+
+            // the path only consists of the edge from the start
+            prev := set &^ (1 << uint(j))
+            if prev == 0 {
+                length[set * m + j] = weights[0][j + 1]
+                continue
+            }
+
+            // extend the best path through the other vertices
+            best := math.Inf(1)
+            for i := 0; i < m; i++ {
+                if prev & (1 << uint(i)) != 0 {
+                    if l := length[prev * m + i] + weights[i + 1][j + 1]; l < best {
+                        best = l
+                    }
+                }
+            }
+            length[set * m + j] = best
+        }
+    }
+
+    // ----------------------------------------------
+    // close the circle and build the tour backwards
+    // ----------------------------------------------
+
+    full := (1 << uint(m)) - 1
+    best, last := math.Inf(1), -1
+    for j := 0; j < m; j++ {
+        if l := length[full * m + j] + weights[j + 1][0]; l < best {
+            best, last = l, j
+        }
+    }
+    if last < 0 {
+        return nil, 0, errors.New("No hamilton circle was found.")
+    }
+
+    tour := make([]int, num)
+    for set, j, k := full, last, num - 1; k > 0; k-- {
+        tour[k] = j + 1
+        prev := set &^ (1 << uint(j))
+
+        // find the vertex the path came from
+        for i := 0; i < m && prev != 0; i++ {
+            if prev & (1 << uint(i)) != 0 && length[prev * m + i] + weights[i + 1][j + 1] == length[set * m + j] {
+                j = i
+                break
+            }
+        }
+        set = prev
+    }
+
+    return graph.tourVertices(tour), best, nil
+}
+
+// returns the number of bytes needed by the Held-Karp algorithm
+func heldKarpMemory(num int) uint64 {
+    m := uint64(num - 1)
+    return (1 << m) * m * 8
+}
@@ -0,0 +1,62 @@
+package algorithm
+
+import (
+    "testing"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the Held-Karp algorithm against the known optimal lengths
+func TestTravelingSalesmanHeldKarp(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_10e.txt": 27.26, "test/K_12.txt": 45.19, "test/K_12e.txt": 36.13} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+
+        tour, length, err := graph.TravelingSalesmanHeldKarp(1 << 30)
+        if err != nil {
+            t.Errorf("Expected no error for %s, got \"%s\".", file, err.Error())
+            continue
+        }
+        validateTour(t, graph, tour)
+        if float64(int(length * 100 + 0.5)) / 100 != optimum {
+            t.Errorf("Expected length %f for %s, got %f.", optimum, file, length)
+        }
+        if l := tourLength(graph.getWeightMatrix(), tourPositions(tour)); l != length {
+            t.Errorf("Expected tour of length %f for %s, got %f.", length, file, l)
+        }
+    }
+}
+
+// test failing because of the memory limit
+func TestTravelingSalesmanHeldKarpMemory(t *testing.T) {
+    g, err := parser.ParseEdgesFile("test/K_12.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+
+    expectError := "Held-Karp needs 0.2 MiB for 12 vertices, but only 0.1 MiB are allowed."
+    if _, _, err := (Graph{g}).TravelingSalesmanHeldKarp(1 << 17); err == nil {
+        t.Error("Expected error, got nil.")
+    } else if msg := err.Error(); msg != expectError {
+        t.Errorf("Expected error \"%s\", got \"%s\".", expectError, msg)
+    }
+}
+
+func BenchmarkTravelingSalesmanHeldKarp12(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/K_12.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+
+    for n := 0; n < b.N; n++ {
+        graph.TravelingSalesmanHeldKarp(1 << 30)
+    }
+
+}
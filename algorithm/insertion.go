@@ -0,0 +1,178 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "math/rand"
+)
+
+// the strategies of choosing the next vertex to insert
+const (
+    insertionNearest = iota
+    insertionFarthest
+    insertionRandom
+    insertionCheapest
+)
+
+// simple wrapper
+func (this Graph) NearestInsertionHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return NearestInsertionHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle built by nearest insertion and its length
+// The vertex nearest to the circle is inserted next, where it adds the least length.
+func NearestInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return insertionHamiltonCircle(graph, start, insertionNearest, nil)
+}
+
+// simple wrapper
+func (this Graph) FarthestInsertionHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return FarthestInsertionHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle built by farthest insertion and its length
+// The vertex farthest from the circle is inserted next, where it adds the least length.
+func FarthestInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return insertionHamiltonCircle(graph, start, insertionFarthest, nil)
+}
+
+// simple wrapper
+func (this Graph) RandomInsertionHamiltonCircle(start graphLib.VertexInterface, seed int64) ([]graphLib.VertexInterface, float64) {
+    return RandomInsertionHamiltonCircle(this, start, seed)
+}
+
+// returns the hamilton circle built by random insertion and its length
+// The vertices are inserted in a random order given by the seed, each where it adds the least length.
+func RandomInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface, seed int64) ([]graphLib.VertexInterface, float64) {
+    return insertionHamiltonCircle(graph, start, insertionRandom, rand.New(rand.NewSource(seed)))
+}
+
+// simple wrapper
+func (this Graph) CheapestInsertionHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return CheapestInsertionHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle built by cheapest insertion and its length
+// The vertex that adds the least length to the circle is inserted next.
+func CheapestInsertionHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return insertionHamiltonCircle(graph, start, insertionCheapest, nil)
+}
+
+// helper for the insertion heuristics, directed graphs are respected
+func insertionHamiltonCircle(graph Graph, start graphLib.VertexInterface, strategy int, r *rand.Rand) ([]graphLib.VertexInterface, float64) {
+    weights := graph.getDistanceMatrix()
+    tour := insertionTour(weights, start.GetPos(), strategy, r)
+    return graph.tourVertices(tour), tourLength(weights, tour)
+}
+
+// returns the circle built by the insertion strategy as vertex positions, starting with the start vertex
+func insertionTour(weights [][]float64, start, strategy int, r *rand.Rand) []int {
+    num := len(weights)
+
+    // the circle is kept as the successor of each vertex
+    // The circle of the start vertex alone uses the edge from start to start with weight 0.
+    next, inTour := make([]int, num), make([]bool, num)
+    next[start], inTour[start] = start, true
+
+    // returns how much longer the circle gets by inserting v between a and its successor
+    cost := func(a, v int) float64 {
+        c := weights[a][v] + weights[v][next[a]] - weights[a][next[a]]
+        if math.IsNaN(c) {
+            // an infinite edge is replaced by infinite ones
+            return math.Inf(1)
+        }
+        return c
+    }
+
+    // returns the vertex of the circle after which v is inserted best
+    bestPosition := func(v int) (int, float64) {
+        best, bestCost := start, cost(start, v)
+        for a := next[start]; a != start; a = next[a] {
+            if c := cost(a, v); c < bestCost {
+                best, bestCost = a, c
+            }
+        }
+        return best, bestCost
+    }
+
+    // the distance of each vertex to the circle, the best position for cheapest insertion
+    distance, position, positionCost := make([]float64, num), make([]int, num), make([]float64, num)
+    for v := range distance {
+        distance[v] = math.Min(weights[start][v], weights[v][start])
+        position[v], positionCost[v] = start, cost(start, v)
+    }
+
+    // the order of random insertion
+    var order []int
+    if strategy == insertionRandom {
+        for _, v := range r.Perm(num) {
+            if v != start {
+                order = append(order, v)
+            }
+        }
+    }
+
+    for n := 1; n < num; n++ {
+
+        /*
+         * 1. Select the vertex to insert.
+         */
+        v := -1
+        switch strategy {
+        case insertionRandom:
+            v = order[n - 1]
+        default:
+            for w := 0; w < num; w++ {
+                if inTour[w] {
+                    continue
+                }
+                if v < 0 ||
+                    strategy == insertionNearest && distance[w] < distance[v] ||
+                    strategy == insertionFarthest && distance[w] > distance[v] ||
+                    strategy == insertionCheapest && positionCost[w] < positionCost[v] {
+                    v = w
+                }
+            }
+        }
+
+        /*
+         * 2. Insert it at the best position.
+         */
+        a := position[v]
+        if strategy != insertionCheapest {
+            a, _ = bestPosition(v)
+        }
+        b := next[a]
+        next[a], next[v], inTour[v] = v, b, true
+
+        /*
+         * 3. Update the distances to the circle and the best positions.
+         */
+        for w := 0; w < num; w++ {
+            if inTour[w] {
+                continue
+            }
+            distance[w] = math.Min(distance[w], math.Min(weights[v][w], weights[w][v]))
+            if strategy != insertionCheapest {
+                continue
+            }
+            if position[w] == a {
+                // the edge from a to b does not exist anymore
+                position[w], positionCost[w] = bestPosition(w)
+            } else {
+                for _, c := range [2]int{a, v} {
+                    if cc := cost(c, w); cc < positionCost[w] {
+                        position[w], positionCost[w] = c, cc
+                    }
+                }
+            }
+        }
+    }
+
+    tour := make([]int, 1, num)
+    tour[0] = start
+    for v := next[start]; v != start; v = next[v] {
+        tour = append(tour, v)
+    }
+    return tour
+}
@@ -0,0 +1,66 @@
+package algorithm
+
+import (
+    "testing"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the insertion heuristics against the optimal length
+func TestInsertionHamiltonCircle(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_12.txt": 45.19} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+        start := graph.GetVertices().Get(1)
+
+        // nearest and cheapest insertion are 2-approximations on metric graphs
+        for name, test := range map[string]struct {
+            heuristic func() ([]graphLib.VertexInterface, float64)
+            factor    float64
+        }{
+            "nearest": {func() ([]graphLib.VertexInterface, float64) { return graph.NearestInsertionHamiltonCircle(start) }, 2},
+            "cheapest": {func() ([]graphLib.VertexInterface, float64) { return graph.CheapestInsertionHamiltonCircle(start) }, 2},
+            "farthest": {func() ([]graphLib.VertexInterface, float64) { return graph.FarthestInsertionHamiltonCircle(start) }, 3},
+            "random": {func() ([]graphLib.VertexInterface, float64) { return graph.RandomInsertionHamiltonCircle(start, 42) }, 3},
+        } {
+            tour, length := test.heuristic()
+            validateTour(t, graph, tour)
+            if tour[0] != start {
+                t.Errorf("Expected %s insertion to start with vertex %d, got %d.", name, start.GetId(), tour[0].GetId())
+            }
+            if l := tourLength(graph.getWeightMatrix(), tourPositions(tour)); l != length {
+                t.Errorf("Expected %s insertion tour of length %f for %s, got %f.", name, length, file, l)
+            }
+            if length < optimum - 0.005 || length > test.factor * optimum {
+                t.Errorf("Expected %s insertion length between %f and %f for %s, got %f.", name, optimum, test.factor * optimum, file, length)
+            }
+        }
+
+        // the same seed gives the same circle
+        _, a := graph.RandomInsertionHamiltonCircle(start, 7)
+        _, b := graph.RandomInsertionHamiltonCircle(start, 7)
+        if a != b {
+            t.Errorf("Expected the same length for the same seed, got %f and %f.", a, b)
+        }
+    }
+}
+
+func BenchmarkFarthestInsertionHamiltonCircle(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/K_100.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    for n := 0; n < b.N; n++ {
+        graph.FarthestInsertionHamiltonCircle(start)
+    }
+
+}
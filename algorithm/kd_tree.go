@@ -0,0 +1,192 @@
+package algorithm
+
+import (
+    "math"
+    "sort"
+)
+
+// a 2-dimensional tree over points in the plane, indexed by vertex positions
+// The tree is stored implicitly: the range [lo, hi) of order is a subtree, its root is the median at (lo + hi) / 2
+// which splits the range by x on even and by y on odd depths. Points can be deactivated and activated again,
+// queries only return active points.
+type kdTree struct {
+    points [][2]float64
+    order  []int
+
+    // the index of each point in order and the number of active points of the subtree with the root at each index
+    index  []int
+    count  []int
+    active []bool
+}
+
+// builds the tree, all points are active or inactive
+func newKdTree(points [][2]float64, active bool) *kdTree {
+    num := len(points)
+    t := &kdTree{
+        points: points,
+        order: make([]int, num),
+        index: make([]int, num),
+        count: make([]int, num),
+        active: make([]bool, num),
+    }
+    for v := range t.order {
+        t.order[v], t.active[v] = v, active
+    }
+    t.build(0, num, 0)
+    for i, v := range t.order {
+        t.index[v] = i
+    }
+    if active {
+        t.initCount(0, num)
+    }
+    return t
+}
+
+// sorts the range by the splitting coordinate and builds the subtrees
+func (this *kdTree) build(lo, hi, depth int) {
+    if hi - lo < 2 {
+        return
+    }
+    sort.Sort(byCoordinate{this.order[lo:hi], this.points, depth % 2})
+    mid := (lo + hi) / 2
+    this.build(lo, mid, depth + 1)
+    this.build(mid + 1, hi, depth + 1)
+}
+
+// sets the counts of the subtree with all points being active
+func (this *kdTree) initCount(lo, hi int) {
+    if lo >= hi {
+        return
+    }
+    mid := (lo + hi) / 2
+    this.count[mid] = hi - lo
+    this.initCount(lo, mid)
+    this.initCount(mid + 1, hi)
+}
+
+// activates or deactivates the point
+func (this *kdTree) setActive(v int, active bool) {
+    if this.active[v] == active {
+        return
+    }
+    this.active[v] = active
+    change := 1
+    if !active {
+        change = -1
+    }
+
+    // update the counts on the way from the root to the point
+    i, lo, hi := this.index[v], 0, len(this.order)
+    for {
+        mid := (lo + hi) / 2
+        this.count[mid] += change
+        if i == mid {
+            return
+        }
+        if i < mid {
+            hi = mid
+        } else {
+            lo = mid + 1
+        }
+    }
+}
+
+// returns the nearest active point to the given one or -1 if there is none
+func (this *kdTree) nearest(p [2]float64) int {
+    result := this.kNearest(p, 1, -1)
+    if len(result) == 0 {
+        return -1
+    }
+    return result[0]
+}
+
+// returns up to k nearest active points to the given one sorted by distance, the point skip is left out
+func (this *kdTree) kNearest(p [2]float64, k int, skip int) []int {
+    q := &kdQuery{tree: this, p: p, k: k, skip: skip}
+    q.search(0, len(this.order), 0)
+    return q.result
+}
+
+// a query for the nearest points
+type kdQuery struct {
+    tree *kdTree
+    p    [2]float64
+    k    int
+    skip int
+
+    // the nearest points found so far and their squared distances, both sorted
+    result    []int
+    distances []float64
+}
+
+// returns the squared distance that a point has to beat to be one of the nearest
+func (this *kdQuery) bound() float64 {
+    if len(this.result) < this.k {
+        return math.Inf(1)
+    }
+    return this.distances[this.k - 1]
+}
+
+// adds the point if it is one of the nearest
+func (this *kdQuery) add(v int) {
+    dx, dy := this.tree.points[v][0] - this.p[0], this.tree.points[v][1] - this.p[1]
+    d := dx * dx + dy * dy
+    if d >= this.bound() {
+        return
+    }
+    i := sort.SearchFloat64s(this.distances, d)
+    if len(this.result) < this.k {
+        this.result, this.distances = append(this.result, 0), append(this.distances, 0)
+    }
+    copy(this.result[i + 1:], this.result[i:])
+    copy(this.distances[i + 1:], this.distances[i:])
+    this.result[i], this.distances[i] = v, d
+}
+
+// searches the subtree, the side of the query point first
+func (this *kdQuery) search(lo, hi, depth int) {
+    if lo >= hi {
+        return
+    }
+    mid := (lo + hi) / 2
+    if this.tree.count[mid] == 0 {
+        return
+    }
+    v := this.tree.order[mid]
+    if this.tree.active[v] && v != this.skip {
+        this.add(v)
+    }
+
+    // the other side is only searched if the splitting line is near enough
+    diff := this.p[depth % 2] - this.tree.points[v][depth % 2]
+    if diff < 0 {
+        this.search(lo, mid, depth + 1)
+        if diff * diff < this.bound() {
+            this.search(mid + 1, hi, depth + 1)
+        }
+    } else {
+        this.search(mid + 1, hi, depth + 1)
+        if diff * diff < this.bound() {
+            this.search(lo, mid, depth + 1)
+        }
+    }
+}
+
+// sorts vertex positions by one coordinate of their points
+type byCoordinate struct {
+    vertices []int
+    points   [][2]float64
+    axis     int
+}
+
+func (this byCoordinate) Len() int {
+    return len(this.vertices)
+}
+
+func (this byCoordinate) Less(i, j int) bool {
+    return this.points[this.vertices[i]][this.axis] < this.points[this.vertices[j]][this.axis]
+}
+
+func (this byCoordinate) Swap(i, j int) {
+    this.vertices[i], this.vertices[j] = this.vertices[j], this.vertices[i]
+}
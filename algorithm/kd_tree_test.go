@@ -0,0 +1,57 @@
+package algorithm
+
+import (
+    "testing"
+    "math/rand"
+)
+
+// test the nearest points of the k-d tree against all points
+func TestKdTree(t *testing.T) {
+    r := rand.New(rand.NewSource(1))
+    points := make([][2]float64, 500)
+    for i := range points {
+        points[i] = [2]float64{float64(r.Intn(100)), r.Float64() * 100}
+    }
+    tree := newKdTree(points, true)
+    weight := euclideanWeight(points)
+
+    for n := 0; n < 400; n++ {
+
+        // deactivate a random point and search the nearest ones of another one
+        tree.setActive(r.Intn(len(points)), false)
+        p := r.Intn(len(points))
+        result := tree.kNearest(points[p], 5, p)
+
+        // the nearest points are sorted and no inactive point is nearer than the last one
+        if len(result) != 5 {
+            t.Fatalf("Expected 5 points, got %d.", len(result))
+        }
+        for i, v := range result {
+            if v == p || !tree.active[v] || i > 0 && weight(p, result[i - 1]) > weight(p, v) {
+                t.Errorf("Expected the nearest active points to %d sorted by distance, got %v.", p, result)
+            }
+        }
+        found := map[int]bool{}
+        for _, v := range result {
+            found[v] = true
+        }
+        for v := range points {
+            if v != p && tree.active[v] && !found[v] && weight(p, v) < weight(p, result[4]) {
+                t.Errorf("Expected %d to be one of the nearest points to %d, got %v.", v, p, result)
+            }
+        }
+    }
+
+    // activate all points again
+    for v := range points {
+        tree.setActive(v, true)
+    }
+    if tree.count[len(points) / 2] != len(points) {
+        t.Errorf("Expected %d active points, got %d.", len(points), tree.count[len(points) / 2])
+    }
+
+    // no active point
+    if v := newKdTree(points, false).nearest(points[0]); v != -1 {
+        t.Errorf("Expected -1, got %d.", v)
+    }
+}
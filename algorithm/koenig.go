@@ -0,0 +1,127 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "github.com/teelevision/fhac-mmi/parser"
+    "errors"
+    "fmt"
+)
+
+// simple wrapper
+func (this Graph) MinVertexCover(matching []graphLib.EdgeInterface) ([]graphLib.VertexInterface, error) {
+    return MinVertexCover(this, matching)
+}
+
+// returns a minimum vertex cover of a bipartite graph using König's theorem
+// The matching has to be a maximum matching as returned by MaxMatching. Fails if a capacity is not 1.
+func MinVertexCover(graph Graph, matching []graphLib.EdgeInterface) ([]graphLib.VertexInterface, error) {
+    cover, err := koenigCover(graph, matching)
+    if err != nil {
+        return nil, err
+    }
+    result := make([]graphLib.VertexInterface, 0, len(matching))
+    for _, v := range graph.GetVertices().All() {
+        if cover[v.GetPos()] {
+            result = append(result, v)
+        }
+    }
+    return result, nil
+}
+
+// simple wrapper
+func (this Graph) MaxIndependentSet(matching []graphLib.EdgeInterface) ([]graphLib.VertexInterface, error) {
+    return MaxIndependentSet(this, matching)
+}
+
+// returns a maximum independent set of a bipartite graph using König's theorem
+// It is the complement of the minimum vertex cover.
+func MaxIndependentSet(graph Graph, matching []graphLib.EdgeInterface) ([]graphLib.VertexInterface, error) {
+    cover, err := koenigCover(graph, matching)
+    if err != nil {
+        return nil, err
+    }
+    result := make([]graphLib.VertexInterface, 0, len(cover))
+    for _, v := range graph.GetVertices().All() {
+        if !cover[v.GetPos()] {
+            result = append(result, v)
+        }
+    }
+    return result, nil
+}
+
+// returns for each vertex position whether the vertex is part of the minimum vertex cover
+// With capacities a matching is no longer a set of disjoint edges, so the theorem does not hold.
+func koenigCover(graph Graph, matching []graphLib.EdgeInterface) ([]bool, error) {
+
+    num := int(graph.GetVertices().Count())
+    vertices := graph.GetVertices()
+    for _, v := range vertices.All() {
+        if c := v.(*parser.GroupVertex).GetCapacity(); c != 1 {
+            return nil, errors.New(fmt.Sprintf("König's theorem needs all capacities to be 1, but vertex %d has capacity %d.", v.GetId(), c))
+        }
+    }
+
+    /*
+     * 1. Build the adjacency by position.
+     */
+    // The edges of the graph are used instead of the edges of the vertices, ...
+    // ... because MaxMatching works on clones that share their edge lists with the vertices.
+    adjacency := make([][]int, num)
+    for _, e := range graph.GetEdges().All() {
+        s, t := e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()
+        adjacency[s] = append(adjacency[s], t)
+        adjacency[t] = append(adjacency[t], s)
+    }
+
+    /*
+     * 2. Map each vertex to its matching partner.
+     */
+    mate := make([]int, num)
+    for i := range mate {
+        mate[i] = -1
+    }
+    for _, e := range matching {
+        s, t := e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()
+        mate[s], mate[t] = t, s
+    }
+
+    /*
+     * 3. Find all vertices reachable by alternating paths from unmatched vertices of group 0.
+     */
+    visited := make([]bool, num)
+    q := make([]int, 0, num)
+    for pos := 0; pos < num; pos++ {
+        if mate[pos] < 0 && vertices.GetPos(pos).(*parser.GroupVertex).GetGroup() == 0 {
+            visited[pos] = true
+            q = append(q, pos)
+        }
+    }
+    for i := 0; i < len(q); i++ {
+
+        // from group 0 go to group 1 over edges that are not matched
+        for _, r := range adjacency[q[i]] {
+            if !visited[r] {
+                visited[r] = true
+
+                // from group 1 go back to group 0 over the matched edge
+                if l := mate[r]; l >= 0 && !visited[l] {
+                    visited[l] = true
+                    q = append(q, l)
+                }
+            }
+        }
+    }
+
+    /*
+     * 4. The cover consists of the unreached vertices of group 0 and the reached vertices of group 1.
+     */
+    cover := make([]bool, num)
+    for pos := 0; pos < num; pos++ {
+        if vertices.GetPos(pos).(*parser.GroupVertex).GetGroup() == 0 {
+            cover[pos] = !visited[pos]
+        } else {
+            cover[pos] = visited[pos]
+        }
+    }
+    return cover, nil
+}
@@ -0,0 +1,67 @@
+package algorithm
+
+import (
+    "testing"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+)
+
+// test the minimum vertex cover and maximum independent set
+func TestKoenig(t *testing.T) {
+
+    // (0)  (1)  (2)
+    //  | \  |  /
+    // (4) (3)  (5)
+    g, err := parser.ParseBipartite(strings.NewReader("6 3 0 3 0 4 1 3 2 3"), false)
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+
+    matching := graph.MaxMatching()
+    if len(matching) != 2 {
+        t.Errorf("Expected 2 matching edges, got %d.", len(matching))
+    }
+
+    // test function
+    test := func(name string, vertices []graphLib.VertexInterface, err error, expect []int) {
+        if err != nil {
+            t.Errorf("Expected %s %v, got error \"%s\".", name, expect, err.Error())
+            return
+        }
+        pos := make([]int, len(vertices))
+        for i, v := range vertices {
+            pos[i] = v.GetPos()
+        }
+        if len(pos) != len(expect) {
+            t.Errorf("Expected %s %v, got %v.", name, expect, pos)
+            return
+        }
+        for i := range pos {
+            if pos[i] != expect[i] {
+                t.Errorf("Expected %s %v, got %v.", name, expect, pos)
+                return
+            }
+        }
+    }
+
+    cover, err := graph.MinVertexCover(matching)
+    test("vertex cover", cover, err, []int{0, 3})
+    independent, err := graph.MaxIndependentSet(matching)
+    test("independent set", independent, err, []int{1, 2, 4, 5})
+
+    // with a capacity other than 1 the theorem does not hold
+    g, err = parser.ParseBipartite(strings.NewReader("6 3 1 1 1 2 1 1 0 3 0 4 1 3 2 3"), true)
+    if err != nil {
+        panic(err)
+    }
+    graph = Graph{g}
+    matching = graph.MaxMatching()
+    if _, err := graph.MinVertexCover(matching); err == nil {
+        t.Error("Expected error for the vertex cover with capacities, got nil.")
+    }
+    if _, err := graph.MaxIndependentSet(matching); err == nil {
+        t.Error("Expected error for the independent set with capacities, got nil.")
+    }
+}
@@ -5,66 +5,75 @@ import (
 )
 
 // simple wrapper
-func (this Graph) KruskalLength() float64 {
-    return KruskalLength(this)
+func (this Graph) Kruskal(start graphLib.VertexInterface) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface) {
+    return Kruskal(this, start)
 }
 
-type kruskalHelperItem struct {
-    vertices []graphLib.VertexInterface
+// kruskal algorithm with result length, the minimal spanning tree and the mapping of the vertices to the ones of the tree
+// It is a MinimalSpanningTreeFunction, the start vertex is not needed. If the graph is not connected,
+// the result is a minimal spanning forest, see KruskalForest.
+func Kruskal(graph Graph, start graphLib.VertexInterface) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface) {
+    length, mst, vMap, _ := KruskalForest(graph)
+    return length, mst, vMap
+}
+
+// simple wrapper
+func (this Graph) KruskalLength() float64 {
+    return KruskalLength(this)
 }
 
 // kruskal algorithm with result length
 func KruskalLength(graph Graph) float64 {
+    length, _, _, _ := KruskalForest(graph)
+    return length
+}
+
+// simple wrapper
+func (this Graph) KruskalForest() (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface, int) {
+    return KruskalForest(this)
+}
+
+// returns the length of the minimal spanning forest, the forest, the mapping of the vertices to the ones of the
+// forest and the number of its components, which is 1 if the graph is connected
+// The direction of the edges is ignored.
+func KruskalForest(graph Graph) (float64, Graph, map[graphLib.VertexInterface]graphLib.VertexInterface, int) {
 
-    // the number of edges and vertices
+    // the number of vertices and the number of edges of a spanning tree
     num := graph.GetVertices().Count()
-    requiredNumEdges := num - 1
+    requiredNumEdges := int(num) - 1
 
-    components := make(map[graphLib.VertexInterface]*kruskalHelperItem, num)
+    // map source to result vertex
+    vMap := make(map[graphLib.VertexInterface]graphLib.VertexInterface, num)
 
-    for _, vertex := range graph.GetVertices().All() {
-        components[vertex] = &kruskalHelperItem{[]graphLib.VertexInterface{vertex}}
+    // the result spanning forest
+    result := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(false, num, num - 1)
+    vertices := graph.GetVertices().All()
+    for _, v := range vertices {
+        vMap[v] = result.NewVertex()
     }
 
+    // the components of the forest
+    components := newUnionFind(int(num))
+
     q := graphLib.NewCheapestEdgeQueue(graph.GetEdges().Count())
     for _, edge := range graph.GetEdges().All() {
         q.PushEdge(edge)
     }
 
     // keep track of the length of the minimal spanning tree
-    length := 0.0
-
-    for n, edge := uint(0), q.PopCheapestEdge(); edge != nil; edge = q.PopCheapestEdge() {
+    length, n := 0.0, 0
 
-        cStart, cEnd := components[edge.GetStartVertex()], components[edge.GetEndVertex()]
+    for edge := q.PopCheapestEdge(); edge != nil && n < requiredNumEdges; edge = q.PopCheapestEdge() {
+        start, end := edge.GetStartVertex(), edge.GetEndVertex()
 
-        // compare pointers, if different, then those are different components
-        if cStart != cEnd {
-
-            // merge the smaller one into the bigger one
-            if len(cStart.vertices) > len(cEnd.vertices) {
-                cStart.vertices = append(cStart.vertices, cEnd.vertices...)
-                for _, e := range cEnd.vertices {
-                    components[e] = cStart
-                }
-            } else {
-                cEnd.vertices = append(cEnd.vertices, cStart.vertices...)
-                for _, e := range cStart.vertices {
-                    components[e] = cEnd
-                }
-            }
-
-            // add length
+        // an edge within a component would close a circle
+        if components.union(start.GetPos(), end.GetPos()) {
             length += edge.GetWeight()
+            result.NewWeightedEdge(vMap[start], vMap[end], edge.GetWeight())
             n++
-
-            // if we got numVertices - 1 edges, we are finished
-            if n >= requiredNumEdges {
-                return length
-            }
         }
     }
 
-    // return the length/weight of the minimal spanning tree
-    return length
-}
\ No newline at end of file
+    // every edge of the forest merges two components
+    return length, Graph{result}, vMap, int(num) - n
+}
@@ -2,9 +2,72 @@ package algorithm
 
 import (
     "testing"
+    "math"
+    "strings"
     "github.com/teelevision/fhac-mmi/parser"
 )
 
+// test the spanning tree of kruskal against the one of prim
+func TestKruskal(t *testing.T) {
+
+    g, err := parser.ParseEdgesFile("test/G_100_200.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    length, mst, vMap := graph.Kruskal(start)
+    primLength, _, _ := graph.Prim(start)
+    if math.Abs(length - primLength) > 1e-6 {
+        t.Errorf("Expected length %f, got %f.", primLength, length)
+    }
+    num := int(graph.GetVertices().Count())
+    if n := int(mst.GetEdges().Count()); n != num - 1 {
+        t.Errorf("Expected %d edges, got %d.", num - 1, n)
+    }
+    if len(vMap) != num || len(DepthFirstSearch(mst, vMap[start])) != num {
+        t.Errorf("Expected a spanning tree that reaches all %d vertices.", num)
+    }
+
+    // it can be used for the double tree algorithm
+    g, err = parser.ParseEdgesFile("test/K_10.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    graph = Graph{g}
+    _, kruskalCircle, err := graph.DoubleTreeHamiltonCircle(Kruskal, graph.GetVertices().Get(0))
+    if err != nil {
+        t.Fatal(err)
+    }
+    _, primCircle, err := graph.DoubleTreeHamiltonCircle(Prim, graph.GetVertices().Get(0))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if kruskalCircle > 2 * 38.41 || primCircle > 2 * 38.41 {
+        t.Errorf("Expected double tree circles shorter than %f, got %f and %f.", 2 * 38.41, kruskalCircle, primCircle)
+    }
+}
+
+// test the spanning forest of a graph that is not connected
+func TestKruskalForest(t *testing.T) {
+
+    g, err := parser.ParseEdges(strings.NewReader("7\n0 1 1\n1 2 2\n0 2 3\n3 4 4\n4 5 5\n3 5 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    length, forest, _, components := Graph{g}.KruskalForest()
+    if length != 8 {
+        t.Errorf("Expected length 8, got %f.", length)
+    }
+    if n := forest.GetEdges().Count(); n != 4 {
+        t.Errorf("Expected 4 edges, got %d.", n)
+    }
+    if components != 3 {
+        t.Errorf("Expected 3 components, got %d.", components)
+    }
+}
+
 func BenchmarkKruskalLength(b *testing.B) {
 
     g, err := parser.ParseEdgesFile("test/G_100_200.txt", true)
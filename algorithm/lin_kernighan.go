@@ -0,0 +1,195 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "sort"
+)
+
+// the maximum number of edges that are exchanged in one move
+const linKernighanMaxDepth = 50
+
+// the number of alternatives that are tried on the first levels of a move
+var linKernighanBreadth = []int{5, 3}
+
+// simple wrapper
+func (this Graph) LinKernighanHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return LinKernighanHamiltonCircle(this, start)
+}
+
+// returns the hamilton circle calculated by the Lin-Kernighan heuristic and its length
+// Starting with the nearest neighbour circle, sequential moves of variable depth are applied
+// until no improving move is left. The graph should be complete. The moves reverse parts of the circle,
+// so directed graphs need the same distances in both directions.
+func LinKernighanHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    weights, err := graph.getSymmetricDistanceMatrix("Lin-Kernighan")
+    if err != nil {
+        return nil, 0, err
+    }
+    tour := linKernighanTour(weights, nearestNeighbourTour(weights, start.GetPos()))
+    return graph.tourVertices(tour), tourLength(weights, tour), nil
+}
+
+// improves the tour given by vertex positions and returns it starting with the same vertex
+func linKernighanTour(weights [][]float64, tour []int) []int {
+    if len(tour) < 5 {
+        return tour
+    }
+    lk := &linKernighan{
+        tourImprover: newTourImprover(matrixWeight(weights), tour, nearestNeighbourLists(weights, localSearchNeighbours)),
+    }
+    lk.run()
+    return lk.rotatedTo(tour[0])
+}
+
+// helper for the Lin-Kernighan heuristic
+// Every move is a sequence of 2-opt moves that share the first vertex t1.
+type linKernighan struct {
+    *tourImprover
+
+    // whether the tour is currently walked backwards
+    backward bool
+
+    // the edges that were added by the current move and must not be removed again
+    added [][2]int
+}
+
+// processes the queue until every vertex has its don't-look bit set
+func (this *linKernighan) run() {
+    for len(this.queue) > 0 {
+        v := this.queue[len(this.queue) - 1]
+        this.queue = this.queue[:len(this.queue) - 1]
+        this.dontLook[v] = true
+
+        // Or-opt moves are not covered by the sequential moves
+        if this.improve(v) || this.orOpt(v) {
+            this.activate(v)
+        }
+    }
+}
+
+// returns the vertex after v in the current direction
+func (this *linKernighan) next(v int) int {
+    if this.backward {
+        return this.pred(v)
+    }
+    return this.succ(v)
+}
+
+// returns the vertex before v in the current direction
+func (this *linKernighan) prev(v int) int {
+    if this.backward {
+        return this.succ(v)
+    }
+    return this.pred(v)
+}
+
+// reverses the path from a to b in the current direction and returns what is needed to undo it
+func (this *linKernighan) reversePath(a, b int) [2]int {
+    i, j := this.index[a], this.index[b]
+    if this.backward {
+        i, j = j, i
+    }
+    if this.reverse(i, j) {
+        this.backward = !this.backward
+    }
+    return [2]int{i, j}
+}
+
+// undoes the reversal
+func (this *linKernighan) undo(r [2]int) {
+    if this.reverse(r[0], r[1]) {
+        this.backward = !this.backward
+    }
+}
+
+// returns whether the edge was added by the current move
+func (this *linKernighan) isAdded(a, b int) bool {
+    for _, e := range this.added {
+        if (e[0] == a && e[1] == b) || (e[0] == b && e[1] == a) {
+            return true
+        }
+    }
+    return false
+}
+
+// tries to find an improving move that removes one of the edges at t1
+func (this *linKernighan) improve(t1 int) bool {
+    for _, backward := range [2]bool{false, true} {
+        this.backward = backward
+        this.added = this.added[:0]
+        t2 := this.next(t1)
+        if this.step(t1, t2, this.weight(t1, t2), 1) {
+            return true
+        }
+    }
+    return false
+}
+
+// a possible continuation of the move
+type linKernighanCandidate struct {
+    t3, t4 int
+    gain   float64
+}
+
+// extends the move: adds the edge from t2 to some t3 and removes the edge from t3 to t4
+// The gain is the weight of the removed edges minus the weight of the added ones so far,
+// not counting the edge that closes the circle. The edge from t1 to t2 is always the one to be replaced next.
+func (this *linKernighan) step(t1, t2 int, gain float64, depth int) bool {
+    w := this.weight
+
+    // collect the candidates, the most promising first
+    candidates := make([]linKernighanCandidate, 0, len(this.neighbours[t2]))
+    for _, t3 := range this.neighbours[t2] {
+        g1 := gain - w(t2, t3)
+        if g1 <= localSearchEpsilon {
+            break
+        }
+        if t3 == t1 || t3 == this.next(t2) {
+            continue
+        }
+        t4 := this.prev(t3)
+        if this.isAdded(t3, t4) {
+            continue
+        }
+        candidates = append(candidates, linKernighanCandidate{t3, t4, g1 + w(t4, t3)})
+    }
+    sort.Sort(linKernighanCandidates(candidates))
+
+    breadth := 1
+    if depth <= len(linKernighanBreadth) {
+        breadth = linKernighanBreadth[depth - 1]
+    }
+    for i := 0; i < breadth && i < len(candidates); i++ {
+        c := candidates[i]
+
+        // t1 -> t2 ... t4 -> t3 becomes t1 -> t4 ... t2 -> t3
+        r := this.reversePath(t2, c.t4)
+        this.added = append(this.added, [2]int{t2, c.t3})
+
+        // close the circle if that is an improvement, otherwise go deeper
+        if c.gain - w(c.t4, t1) > localSearchEpsilon || (depth < linKernighanMaxDepth && this.step(t1, c.t4, c.gain, depth + 1)) {
+            this.activate(t1, t2, c.t3, c.t4)
+            return true
+        }
+
+        // undo
+        this.added = this.added[:len(this.added) - 1]
+        this.undo(r)
+    }
+    return false
+}
+
+// sorts candidates by gain, the highest first
+type linKernighanCandidates []linKernighanCandidate
+
+func (this linKernighanCandidates) Len() int {
+    return len(this)
+}
+
+func (this linKernighanCandidates) Less(i, j int) bool {
+    return this[i].gain > this[j].gain
+}
+
+func (this linKernighanCandidates) Swap(i, j int) {
+    this[i], this[j] = this[j], this[i]
+}
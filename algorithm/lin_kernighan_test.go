@@ -0,0 +1,47 @@
+package algorithm
+
+import (
+    "testing"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the Lin-Kernighan heuristic against nearest neighbour and the optimal length
+func TestLinKernighanHamiltonCircle(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_12.txt": 45.19, "test/K_100.txt": 0} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+        start := graph.GetVertices().Get(0)
+
+        tour, length, err := graph.LinKernighanHamiltonCircle(start)
+        if err != nil {
+            t.Fatal(err)
+        }
+        validateTour(t, graph, tour)
+        if _, nn := graph.NearestNeighbourHamiltonCircle(start); length > nn {
+            t.Errorf("Expected length of at most %f for %s, got %f.", nn, file, length)
+        }
+        if length < optimum - 0.005 {
+            t.Errorf("Expected length of at least %f for %s, got %f.", optimum, file, length)
+        }
+    }
+}
+
+func BenchmarkLinKernighanHamiltonCircle(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/K_100.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    for n := 0; n < b.N; n++ {
+        graph.LinKernighanHamiltonCircle(start)
+    }
+
+}
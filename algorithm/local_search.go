@@ -0,0 +1,362 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "sort"
+)
+
+// the number of nearest neighbours that are considered for each vertex
+const localSearchNeighbours = 10
+
+// the minimum gain of a move to be applied
+const localSearchEpsilon = 1e-9
+
+// simple wrapper
+func (this Graph) ImproveHamiltonCircle(tour []graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    return ImproveHamiltonCircle(this, tour)
+}
+
+// improves the hamilton circle with 2-opt, Or-opt and 3-opt moves until no improving move is left
+// The moves only connect vertices to their nearest neighbours. The result starts with the same vertex.
+// The moves reverse paths, so directed graphs need the same distances in both directions.
+func ImproveHamiltonCircle(graph Graph, tour []graphLib.VertexInterface) ([]graphLib.VertexInterface, float64, error) {
+    weights, err := graph.getSymmetricDistanceMatrix("Local search")
+    if err != nil {
+        return nil, 0, err
+    }
+    positions := improveTour(weights, tourPositions(tour))
+    return graph.tourVertices(positions), tourLength(weights, positions), nil
+}
+
+// improves the tour given by vertex positions and returns it starting with the same vertex
+func improveTour(weights [][]float64, tour []int) []int {
+    if len(tour) < 5 {
+        return tour
+    }
+    t := newTourImprover(matrixWeight(weights), tour, nearestNeighbourLists(weights, localSearchNeighbours))
+    t.run()
+    return t.rotatedTo(tour[0])
+}
+
+// returns the k nearest neighbours of each vertex sorted by weight
+func nearestNeighbourLists(weights [][]float64, k int) [][]int {
+    num := len(weights)
+    neighbours := make([][]int, num)
+    for v := range neighbours {
+        candidates := make([]int, 0, num - 1)
+        for w := 0; w < num; w++ {
+            if w != v && !math.IsInf(weights[v][w], 1) {
+                candidates = append(candidates, w)
+            }
+        }
+        sort.Sort(byWeightFrom{candidates, weights[v]})
+        if len(candidates) > k {
+            candidates = candidates[:k]
+        }
+        neighbours[v] = candidates
+    }
+    return neighbours
+}
+
+// sorts vertex positions by their weight from a fixed vertex
+type byWeightFrom struct {
+    vertices []int
+    weights  []float64
+}
+
+func (this byWeightFrom) Len() int {
+    return len(this.vertices)
+}
+
+func (this byWeightFrom) Less(i, j int) bool {
+    return this.weights[this.vertices[i]] < this.weights[this.vertices[j]]
+}
+
+func (this byWeightFrom) Swap(i, j int) {
+    this.vertices[i], this.vertices[j] = this.vertices[j], this.vertices[i]
+}
+
+// returns the weight of the edge between the vertices at two positions
+type weightFunction func(a, b int) float64
+
+// returns the weight function that looks up the weight matrix
+func matrixWeight(weights [][]float64) weightFunction {
+    return func(a, b int) float64 {
+        return weights[a][b]
+    }
+}
+
+// helper for the local search
+type tourImprover struct {
+    weight     weightFunction
+    neighbours [][]int
+    num        int
+
+    // the vertex at each position of the tour and the position of each vertex in the tour
+    tour  []int
+    index []int
+
+    // don't-look bits and the queue of vertices that are worth to look at
+    dontLook []bool
+    queue    []int
+}
+
+// inits the helper with all vertices being active
+func newTourImprover(weight weightFunction, tour []int, neighbours [][]int) *tourImprover {
+    num := len(tour)
+    t := &tourImprover{
+        weight: weight,
+        neighbours: neighbours,
+        num: num,
+        tour: append([]int{}, tour...),
+        index: make([]int, len(neighbours)),
+        dontLook: make([]bool, len(neighbours)),
+        queue: make([]int, 0, num),
+    }
+    for i, v := range t.tour {
+        t.index[v] = i
+    }
+    for i := num - 1; i >= 0; i-- {
+        t.queue = append(t.queue, t.tour[i])
+    }
+    return t
+}
+
+// returns the vertex after v
+func (this *tourImprover) succ(v int) int {
+    return this.tour[(this.index[v] + 1) % this.num]
+}
+
+// returns the vertex before v
+func (this *tourImprover) pred(v int) int {
+    return this.tour[(this.index[v] + this.num - 1) % this.num]
+}
+
+// returns the number of steps from a to b in tour direction
+func (this *tourImprover) between(a, b int) int {
+    return (this.index[b] - this.index[a] + this.num) % this.num
+}
+
+// returns the tour so that it starts with the given vertex
+func (this *tourImprover) rotatedTo(v int) []int {
+    i := this.index[v]
+    return append(append(make([]int, 0, this.num), this.tour[i:]...), this.tour[:i]...)
+}
+
+// activates the vertices again
+func (this *tourImprover) activate(vertices ...int) {
+    for _, v := range vertices {
+        if this.dontLook[v] {
+            this.dontLook[v] = false
+            this.queue = append(this.queue, v)
+        }
+    }
+}
+
+// processes the queue until every vertex has its don't-look bit set
+func (this *tourImprover) run() {
+    // try the moves from cheap to expensive
+    this.runMoves(this.twoOpt, this.orOpt, this.threeOpt)
+}
+
+// processes the queue with the given moves until every vertex has its don't-look bit set
+func (this *tourImprover) runMoves(moves ...func(int) bool) {
+    for len(this.queue) > 0 {
+        v := this.queue[len(this.queue) - 1]
+        this.queue = this.queue[:len(this.queue) - 1]
+        this.dontLook[v] = true
+
+        for _, move := range moves {
+            if move(v) {
+                this.activate(v)
+                break
+            }
+        }
+    }
+}
+
+// reverses the path from tour position i to j
+// Since the tour is a circle, the shorter one of the path and its complement is reversed.
+// Returns true if the complement was reversed, which also reverses the direction of the tour.
+func (this *tourImprover) reverse(i, j int) bool {
+    length, complement := (j - i + this.num) % this.num + 1, false
+    if 2 * length > this.num {
+        i, j = (j + 1) % this.num, (i + this.num - 1) % this.num
+        length, complement = this.num - length, true
+    }
+    for ; length > 1; length -= 2 {
+        a, b := this.tour[i], this.tour[j]
+        this.tour[i], this.tour[j] = b, a
+        this.index[a], this.index[b] = j, i
+        i, j = (i + 1) % this.num, (j + this.num - 1) % this.num
+    }
+    return complement
+}
+
+// replaces the tour by the given sequence of vertices
+func (this *tourImprover) replace(tour []int) {
+    copy(this.tour, tour)
+    for i, v := range this.tour {
+        this.index[v] = i
+    }
+}
+
+// tries to replace an edge at v and another one by two shorter ones
+func (this *tourImprover) twoOpt(a int) bool {
+    w := this.weight
+
+    // edge to the successor
+    b := this.succ(a)
+    for _, c := range this.neighbours[a] {
+        g1 := w(a, b) - w(a, c)
+        if g1 <= localSearchEpsilon {
+            break
+        }
+        d := this.succ(c)
+        if c == b || d == a {
+            continue
+        }
+        if gain := g1 + w(c, d) - w(b, d); gain > localSearchEpsilon {
+            // a -> c ... b -> d
+            this.reverse(this.index[b], this.index[c])
+            this.activate(a, b, c, d)
+            return true
+        }
+    }
+
+    // edge to the predecessor
+    b = this.pred(a)
+    for _, c := range this.neighbours[a] {
+        g1 := w(a, b) - w(a, c)
+        if g1 <= localSearchEpsilon {
+            break
+        }
+        d := this.pred(c)
+        if c == b || d == a {
+            continue
+        }
+        if gain := g1 + w(c, d) - w(b, d); gain > localSearchEpsilon {
+            // d -> b ... c -> a
+            this.reverse(this.index[c], this.index[b])
+            this.activate(a, b, c, d)
+            return true
+        }
+    }
+
+    return false
+}
+
+// tries to move a segment of up to 3 vertices that starts or ends at v to another place
+func (this *tourImprover) orOpt(v int) bool {
+    w := this.weight
+    for length := 1; length <= 3 && length < this.num - 2; length++ {
+        for _, s1 := range [2]int{v, this.tour[(this.index[v] + this.num - length + 1) % this.num]} {
+            sL := this.tour[(this.index[s1] + length - 1) % this.num]
+            p, nx := this.pred(s1), this.succ(sL)
+
+            // the gain of removing the segment
+            g := w(p, s1) + w(sL, nx) - w(p, nx)
+            if g <= localSearchEpsilon {
+                continue
+            }
+
+            // try to insert the segment next to a neighbour of one of its ends
+            for _, end := range [2]int{s1, sL} {
+                other := sL
+                if end == sL {
+                    other = s1
+                }
+                for _, c := range this.neighbours[end] {
+                    if w(end, c) >= g {
+                        break
+                    }
+                    if this.between(s1, c) < length {
+                        // c is part of the segment
+                        continue
+                    }
+
+                    // insert between c and its successor: c -> end ... other -> f
+                    if f := this.succ(c); f != s1 {
+                        if gain := g - w(c, end) - w(other, f) + w(c, f); gain > localSearchEpsilon {
+                            this.moveSegment(s1, length, c, end == sL)
+                            this.activate(p, nx, s1, sL, c, f)
+                            return true
+                        }
+                    }
+
+                    // insert between c and its predecessor: e -> other ... end -> c
+                    if e := this.pred(c); e != sL {
+                        if gain := g - w(e, other) - w(end, c) + w(e, c); gain > localSearchEpsilon {
+                            this.moveSegment(s1, length, e, end == s1)
+                            this.activate(p, nx, s1, sL, c, e)
+                            return true
+                        }
+                    }
+                }
+            }
+        }
+    }
+    return false
+}
+
+// moves the segment that starts at s1 behind vertex c, reversing it if requested
+func (this *tourImprover) moveSegment(s1, length, c int, reversed bool) {
+    segment := make([]int, length)
+    for i := range segment {
+        segment[i] = this.tour[(this.index[s1] + i) % this.num]
+    }
+    if reversed {
+        reverseInts(segment)
+    }
+
+    // walk the rest of the tour starting behind the segment
+    result := make([]int, 0, this.num)
+    for i, pos := 0, (this.index[s1] + length) % this.num; i < this.num - length; i, pos = i + 1, (pos + 1) % this.num {
+        result = append(result, this.tour[pos])
+        if this.tour[pos] == c {
+            result = append(result, segment...)
+        }
+    }
+    this.replace(result)
+}
+
+// tries to exchange two consecutive segments (a pure sequential 3-opt move)
+// The tour t1 -> t2 ... t3 -> t4 ... t5 -> t6 becomes t1 -> t4 ... t5 -> t2 ... t3 -> t6.
+func (this *tourImprover) threeOpt(t1 int) bool {
+    w := this.weight
+    t2 := this.succ(t1)
+    for _, t4 := range this.neighbours[t1] {
+        g1 := w(t1, t2) - w(t1, t4)
+        if g1 <= localSearchEpsilon {
+            break
+        }
+        if this.between(t1, t4) < 2 {
+            continue
+        }
+        t3 := this.pred(t4)
+        for _, t5 := range this.neighbours[t2] {
+            g2 := g1 + w(t3, t4) - w(t5, t2)
+            if g2 <= localSearchEpsilon {
+                break
+            }
+            if t5 == t1 || this.between(t1, t5) < this.between(t1, t4) {
+                continue
+            }
+            t6 := this.succ(t5)
+            if gain := g2 + w(t5, t6) - w(t3, t6); gain > localSearchEpsilon {
+                i2, i4, i5 := this.between(t1, t2), this.between(t1, t4), this.between(t1, t5)
+                old := this.rotatedTo(t1)
+                result := make([]int, 0, this.num)
+                result = append(result, t1)
+                result = append(result, old[i4:i5 + 1]...)
+                result = append(result, old[i2:i4]...)
+                result = append(result, old[i5 + 1:]...)
+                this.replace(result)
+                this.activate(t1, t2, t3, t4, t5, t6)
+                return true
+            }
+        }
+    }
+    return false
+}
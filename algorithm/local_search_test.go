@@ -0,0 +1,62 @@
+package algorithm
+
+import (
+    "testing"
+    "github.com/teelevision/fhac-mmi/parser"
+    "math"
+)
+
+// test that the local search improves the double tree result
+func TestImproveHamiltonCircle(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_12.txt": 45.19, "test/K_100.txt": 0} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+        start := graph.GetVertices().Get(0)
+
+        tour, length, err := graph.DoubleTreeHamiltonCircle(Prim, start)
+        if err != nil {
+            t.Fatal(err)
+        }
+        improved, improvedLength, err := graph.ImproveHamiltonCircle(tour)
+        if err != nil {
+            t.Fatal(err)
+        }
+        validateTour(t, graph, improved)
+
+        if improved[0] != start {
+            t.Errorf("Expected tour to start with vertex %d, got %d.", start.GetPos(), improved[0].GetPos())
+        }
+        if improvedLength > length {
+            t.Errorf("Expected length of at most %f for %s, got %f.", length, file, improvedLength)
+        }
+        if l := tourLength(graph.getWeightMatrix(), tourPositions(improved)); math.Abs(l - improvedLength) > 1e-9 {
+            t.Errorf("Expected returned length %f to match the tour length %f.", improvedLength, l)
+        }
+        if improvedLength < optimum - 0.005 {
+            t.Errorf("Expected length of at least %f for %s, got %f.", optimum, file, improvedLength)
+        }
+    }
+}
+
+func BenchmarkImproveHamiltonCircle(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/K_100.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    tour, _, err := graph.DoubleTreeHamiltonCircle(Prim, graph.GetVertices().Get(0))
+    if err != nil {
+        panic(err)
+    }
+
+    for n := 0; n < b.N; n++ {
+        graph.ImproveHamiltonCircle(tour)
+    }
+
+}
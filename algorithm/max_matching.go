@@ -10,7 +10,8 @@ func (this Graph) MaxMatching() ([]graphLib.EdgeInterface) {
     return MaxMatching(this)
 }
 
-// returns the maximum flow using the Edmonds-Karp algorithm
+// returns the maximum matching using the Edmonds-Karp algorithm
+// Each vertex may be matched with up to as many vertices as its capacity.
 func MaxMatching(graph Graph) ([]graphLib.EdgeInterface) {
 
     /*
@@ -38,21 +39,23 @@ func MaxMatching(graph Graph) ([]graphLib.EdgeInterface) {
 
     /*
      * 3. Connect super source to all vertices in group 0 and all vertices in group 1 to super target.
+     *    The capacity of these edges is the capacity of the vertex.
      */
     for _, v := range G.GetVertices().All() {
         if v != superSource && v != superTarget {
+            capacity := float64(v.(*parser.GroupVertex).GetCapacity())
             if v.(*parser.GroupVertex).GetGroup() == 0 {
-                G.NewWeightedEdge(superSource, v, 1.0)
+                G.NewWeightedEdge(superSource, v, capacity)
             } else {
-                G.NewWeightedEdge(v, superTarget, 1.0)
+                G.NewWeightedEdge(v, superTarget, capacity)
             }
         }
     }
 
     /*
-     * 4. Set capacity of all edges to 1.
+     * 4. Set capacity of all edges between the groups to 1.
      */
-    // already done in 1. and 3. in form of weights
+    // already done in 1. in form of weights
 
     /*
      * 5. Find maximum flow between the super source and target.
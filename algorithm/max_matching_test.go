@@ -0,0 +1,31 @@
+package algorithm
+
+import (
+    "testing"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the maximum matching with capacities
+func TestMaxMatchingWithCapacities(t *testing.T) {
+
+    // test function
+    test := func(input string, withCapacities bool, num int) {
+        g, err := parser.ParseBipartite(strings.NewReader(input), withCapacities)
+        if err != nil {
+            panic(err)
+        }
+        if n := len(Graph{g}.MaxMatching()); n != num {
+            t.Errorf("Expected %d matching edges, got %d.", num, n)
+        }
+    }
+
+    // (0)  (1)  (2)
+    //  | \  |  /
+    // (4) (3)  (5)
+    edges := "0 3 0 4 1 3 2 3"
+    test("6 3 " + edges, false, 2)
+    test("6 3 1 1 1 1 1 1 " + edges, true, 2)
+    test("6 3 2 1 1 3 1 1 " + edges, true, 4)
+    test("6 3 2 1 1 2 1 1 " + edges, true, 3)
+}
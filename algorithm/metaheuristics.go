@@ -0,0 +1,296 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "math/rand"
+    "time"
+)
+
+// the number of iterations of simulated annealing and generations of the genetic algorithm if no limit is given
+const (
+    annealingDefaultIterations = 1000000
+    geneticDefaultGenerations  = 1000
+)
+
+// a function that evaluates a tour given by vertex positions using the weights of the graph, lower is better
+type TourEvaluationFunction func(weights [][]float64, tour []int) float64
+
+// a function that returns the next temperature of simulated annealing
+type CoolingSchedule func(temperature float64, iteration int) float64
+
+// returns the cooling schedule that multiplies the temperature by alpha in every iteration
+func GeometricCooling(alpha float64) CoolingSchedule {
+    return func(temperature float64, iteration int) float64 {
+        return temperature * alpha
+    }
+}
+
+// returns the cooling schedule of Lundy and Mees: t / (1 + beta * t)
+func LundyMeesCooling(beta float64) CoolingSchedule {
+    return func(temperature float64, iteration int) float64 {
+        return temperature / (1 + beta * temperature)
+    }
+}
+
+// the seed and the limits of the metaheuristics
+// The search stops at the first limit that is reached, a zero limit is ignored.
+type MetaheuristicLimits struct {
+    Seed       int64
+    Iterations int
+    TimeLimit  time.Duration
+}
+
+// the options of simulated annealing
+// The initial temperature is estimated from random moves if it is 0. The cooling is geometric if nil.
+type AnnealingOptions struct {
+    MetaheuristicLimits
+    Temperature float64
+    Cooling     CoolingSchedule
+}
+
+// the options of the genetic algorithm, the iterations are the generations
+type GeneticOptions struct {
+    MetaheuristicLimits
+    Population   int
+    MutationRate float64
+}
+
+// returns whether a limit is reached after the number of iterations
+func (this MetaheuristicLimits) reached(iterations int, since time.Time) bool {
+    return this.Iterations > 0 && iterations >= this.Iterations || this.TimeLimit > 0 && time.Since(since) >= this.TimeLimit
+}
+
+// returns the limits with the default number of iterations if there is no limit
+func (this MetaheuristicLimits) withDefault(iterations int) MetaheuristicLimits {
+    if this.Iterations <= 0 && this.TimeLimit <= 0 {
+        this.Iterations = iterations
+    }
+    return this
+}
+
+// simple wrapper
+func (this Graph) SimulatedAnnealingHamiltonCircle(start graphLib.VertexInterface, evaluate TourEvaluationFunction, options AnnealingOptions) ([]graphLib.VertexInterface, float64) {
+    return SimulatedAnnealingHamiltonCircle(this, start, evaluate, options)
+}
+
+// returns the hamilton circle found by simulated annealing and its evaluation
+// It starts with the nearest neighbour circle and moves by reversing paths and moving single vertices.
+// Worse circles are accepted with the probability exp(-delta / temperature). The evaluation is the length if nil.
+func SimulatedAnnealingHamiltonCircle(graph Graph, start graphLib.VertexInterface, evaluate TourEvaluationFunction, options AnnealingOptions) ([]graphLib.VertexInterface, float64) {
+    if evaluate == nil {
+        evaluate = tourLength
+    }
+    weights := graph.getDistanceMatrix()
+    tour := simulatedAnnealingTour(weights, start.GetPos(), evaluate, options)
+    return graph.tourVertices(tour), evaluate(weights, tour)
+}
+
+// returns the best circle found by simulated annealing as vertex positions, starting with the start vertex
+func simulatedAnnealingTour(weights [][]float64, start int, evaluate TourEvaluationFunction, options AnnealingOptions) []int {
+    startTime, num := time.Now(), len(weights)
+    tour := nearestNeighbourTour(weights, start)
+    if num < 4 {
+        return tour
+    }
+    r := rand.New(rand.NewSource(options.Seed))
+    limits := options.withDefault(annealingDefaultIterations)
+
+    // a random move and the move that undoes it, the start vertex stays at position 0
+    move := func() func() {
+        i, j := 1 + r.Intn(num - 1), 1 + r.Intn(num - 1)
+        for i == j {
+            j = 1 + r.Intn(num - 1)
+        }
+        if r.Intn(2) == 0 {
+            if i > j {
+                i, j = j, i
+            }
+            reverseInts(tour[i:j + 1])
+            return func() {
+                reverseInts(tour[i:j + 1])
+            }
+        }
+        moveInt(tour, i, j)
+        return func() {
+            moveInt(tour, j, i)
+        }
+    }
+
+    current := evaluate(weights, tour)
+    best, bestTour := current, append([]int{}, tour...)
+
+    // estimate the temperature by the average change of random moves
+    temperature := options.Temperature
+    if temperature <= 0 {
+        sum, count := 0.0, 0
+        for i := 0; i < 100; i++ {
+            undo := move()
+            if d := math.Abs(evaluate(weights, tour) - current); !math.IsNaN(d) && !math.IsInf(d, 0) {
+                sum, count = sum + d, count + 1
+            }
+            undo()
+        }
+        temperature = 1
+        if count > 0 && sum > 0 {
+            temperature = sum / float64(count)
+        }
+    }
+
+    // cool down to a thousandth of the temperature within the iterations
+    cooling := options.Cooling
+    if cooling == nil {
+        alpha := 0.9999
+        if limits.Iterations > 0 {
+            alpha = math.Pow(1e-3, 1 / float64(limits.Iterations))
+        }
+        cooling = GeometricCooling(alpha)
+    }
+
+    for iteration := 0; !limits.reached(iteration, startTime); iteration++ {
+        undo := move()
+        if next := evaluate(weights, tour); next <= current || r.Float64() < math.Exp((current - next) / temperature) {
+            current = next
+            if current < best {
+                best = current
+                copy(bestTour, tour)
+            }
+        } else {
+            undo()
+        }
+        temperature = cooling(temperature, iteration)
+    }
+
+    return bestTour
+}
+
+// moves the element at position i to position j, shifting the elements in between
+func moveInt(a []int, i, j int) {
+    v := a[i]
+    if i < j {
+        copy(a[i:j], a[i + 1:j + 1])
+    } else {
+        copy(a[j + 1:i + 1], a[j:i])
+    }
+    a[j] = v
+}
+
+// simple wrapper
+func (this Graph) GeneticHamiltonCircle(start graphLib.VertexInterface, evaluate TourEvaluationFunction, options GeneticOptions) ([]graphLib.VertexInterface, float64) {
+    return GeneticHamiltonCircle(this, start, evaluate, options)
+}
+
+// returns the hamilton circle found by the genetic algorithm and its evaluation
+// The population starts with random circles. Children are created by order crossover of two parents chosen by
+// tournaments, then mutated by reversing a random path. The best circle always survives. The evaluation is the length if nil.
+func GeneticHamiltonCircle(graph Graph, start graphLib.VertexInterface, evaluate TourEvaluationFunction, options GeneticOptions) ([]graphLib.VertexInterface, float64) {
+    if evaluate == nil {
+        evaluate = tourLength
+    }
+    weights := graph.getDistanceMatrix()
+    tour := geneticTour(weights, start.GetPos(), evaluate, options)
+    return graph.tourVertices(tour), evaluate(weights, tour)
+}
+
+// an individual of the genetic algorithm
+type geneticIndividual struct {
+    tour  []int
+    value float64
+}
+
+// returns the best circle found by the genetic algorithm as vertex positions, starting with the start vertex
+func geneticTour(weights [][]float64, start int, evaluate TourEvaluationFunction, options GeneticOptions) []int {
+    startTime, num := time.Now(), len(weights)
+    if num < 4 {
+        return nearestNeighbourTour(weights, start)
+    }
+    r := rand.New(rand.NewSource(options.Seed))
+    limits := options.withDefault(geneticDefaultGenerations)
+    size, mutationRate := options.Population, options.MutationRate
+    if size < 2 {
+        size = 50
+    }
+    if mutationRate <= 0 {
+        mutationRate = 0.1
+    }
+
+    // random circles with the start vertex at position 0
+    population := make([]geneticIndividual, size)
+    best := 0
+    for i := range population {
+        tour := make([]int, 1, num)
+        tour[0] = start
+        for _, v := range r.Perm(num) {
+            if v != start {
+                tour = append(tour, v)
+            }
+        }
+        population[i] = geneticIndividual{tour, evaluate(weights, tour)}
+        if population[i].value < population[best].value {
+            best = i
+        }
+    }
+
+    // returns the better one of two random individuals
+    tournament := func() geneticIndividual {
+        a, b := population[r.Intn(size)], population[r.Intn(size)]
+        if b.value < a.value {
+            return b
+        }
+        return a
+    }
+
+    for generation := 0; !limits.reached(generation, startTime); generation++ {
+        next := make([]geneticIndividual, 1, size)
+        next[0] = population[best]
+        best = 0
+        for len(next) < size {
+            child := orderCrossover(tournament().tour, r.Intn(num - 1) + 1, r.Intn(num - 1) + 1, tournament().tour)
+            if r.Float64() < mutationRate {
+                i, j := 1 + r.Intn(num - 1), 1 + r.Intn(num - 1)
+                if i > j {
+                    i, j = j, i
+                }
+                reverseInts(child[i:j + 1])
+            }
+            next = append(next, geneticIndividual{child, evaluate(weights, child)})
+            if next[len(next) - 1].value < next[best].value {
+                best = len(next) - 1
+            }
+        }
+        population = next
+    }
+
+    return population[best].tour
+}
+
+// returns the child of the order crossover (OX) that keeps position 0
+// The path from i to j of the first parent is copied, the remaining positions are filled in the order of the second parent.
+func orderCrossover(first []int, i, j int, second []int) []int {
+    num := len(first)
+    if i > j {
+        i, j = j, i
+    }
+    child, used := make([]int, num), make([]bool, num)
+    child[0], used[first[0]] = first[0], true
+    for k := i; k <= j; k++ {
+        child[k], used[first[k]] = first[k], true
+    }
+
+    // fill the positions behind the path, then the ones before it
+    pos := (j + 1) % num
+    if pos == 0 {
+        pos = 1
+    }
+    for k := 0; k < num - 1; k++ {
+        v := second[(j + 1 + k - 1) % (num - 1) + 1]
+        if used[v] {
+            continue
+        }
+        child[pos], used[v] = v, true
+        if pos++; pos == num {
+            pos = 1
+        }
+    }
+    return child
+}
@@ -0,0 +1,84 @@
+package algorithm
+
+import (
+    "testing"
+    "time"
+    "github.com/teelevision/fhac-mmi/parser"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+)
+
+// test simulated annealing and the genetic algorithm against the optimal length
+func TestMetaheuristics(t *testing.T) {
+    g, err := parser.ParseEdgesFile("test/K_10.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    start := graph.GetVertices().Get(2)
+    limits := MetaheuristicLimits{Seed: 42, Iterations: 20000}
+
+    for name, result := range map[string]func() ([]graphLib.VertexInterface, float64){
+        "simulated annealing": func() ([]graphLib.VertexInterface, float64) {
+            return graph.SimulatedAnnealingHamiltonCircle(start, nil, AnnealingOptions{MetaheuristicLimits: limits})
+        },
+        "simulated annealing (Lundy-Mees)": func() ([]graphLib.VertexInterface, float64) {
+            return graph.SimulatedAnnealingHamiltonCircle(start, nil, AnnealingOptions{MetaheuristicLimits: limits, Temperature: 10, Cooling: LundyMeesCooling(0.001)})
+        },
+        "genetic": func() ([]graphLib.VertexInterface, float64) {
+            return graph.GeneticHamiltonCircle(start, nil, GeneticOptions{MetaheuristicLimits: MetaheuristicLimits{Seed: 42, Iterations: 300}})
+        },
+    } {
+        tour, length := result()
+        validateTour(t, graph, tour)
+        if tour[0] != start {
+            t.Errorf("Expected %s to start with vertex %d, got %d.", name, start.GetId(), tour[0].GetId())
+        }
+        if float64(int(length * 100 + 0.5)) / 100 != 38.41 {
+            t.Errorf("Expected %s length 38.41, got %f.", name, length)
+        }
+        if _, again := result(); again != length {
+            t.Errorf("Expected %s to be reproducible, got %f and %f.", name, length, again)
+        }
+    }
+}
+
+// test the evaluation callback and the time limit
+func TestMetaheuristicsEvaluation(t *testing.T) {
+    g, err := parser.ParseEdgesFile("test/K_12.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    start := graph.GetVertices().Get(0)
+
+    // prefer circles that visit vertex 1 right after the start
+    evaluate := func(weights [][]float64, tour []int) float64 {
+        if tour[1] == 1 {
+            return tourLength(weights, tour)
+        }
+        return tourLength(weights, tour) + 1000
+    }
+    limits := MetaheuristicLimits{Seed: 1, TimeLimit: 50 * time.Millisecond}
+    begin := time.Now()
+    tour, value := graph.GeneticHamiltonCircle(start, evaluate, GeneticOptions{MetaheuristicLimits: limits})
+    if d := time.Since(begin); d > time.Second {
+        t.Errorf("Expected the time limit to stop the search, took %s.", d)
+    }
+    if tour[1].GetPos() != 1 || value >= 1000 {
+        t.Errorf("Expected vertex 1 after the start, got %d (%f).", tour[1].GetPos(), value)
+    }
+}
+
+// test the order crossover
+func TestOrderCrossover(t *testing.T) {
+    child := orderCrossover([]int{0, 1, 2, 3, 4, 5, 6}, 2, 4, []int{0, 6, 5, 4, 3, 2, 1})
+    expect := []int{0, 5, 2, 3, 4, 1, 6}
+    for i := range expect {
+        if child[i] != expect[i] {
+            t.Errorf("Expected child %v, got %v.", expect, child)
+            break
+        }
+    }
+}
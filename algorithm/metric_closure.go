@@ -0,0 +1,152 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "errors"
+)
+
+// simple wrapper
+func (this Graph) MetricClosure() (Graph, [][]int, error) {
+    return MetricClosure(this)
+}
+
+// returns the metric closure: the complete graph with the lengths of the shortest paths as weights
+// The vertices have the same ids and positions as in the given graph. Unreachable pairs are not connected.
+// Also returns the previous vertex on each shortest path, which is needed to expand tours with ExpandWalk.
+func MetricClosure(graph Graph) (Graph, [][]int, error) {
+    distances, prev, err := shortestPathMatrix(graph)
+    if err != nil {
+        return Graph{}, nil, err
+    }
+
+    num := len(distances)
+    closure := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(graph.IsDirected(), uint(num), uint(num * num))
+    for _, v := range graph.GetVertices().All() {
+        closure.NewVertexWithId(v.GetId())
+    }
+    vertices := closure.GetVertices()
+    for s := 0; s < num; s++ {
+        for t := 0; t < num; t++ {
+            // undirected graphs only need one edge per pair
+            if s != t && !math.IsInf(distances[s][t], 1) && (s < t || graph.IsDirected()) {
+                closure.NewWeightedEdge(vertices.GetPos(s), vertices.GetPos(t), distances[s][t])
+            }
+        }
+    }
+
+    return Graph{closure}, prev, nil
+}
+
+// simple wrapper
+func (this Graph) ExpandWalk(prev [][]int, tour []graphLib.VertexInterface) []graphLib.VertexInterface {
+    return ExpandWalk(this, prev, tour)
+}
+
+// returns the closed walk in the graph that follows the shortest paths between the consecutive vertices of the tour
+// The tour is one of the metric closure, prev is the one returned by MetricClosure. The walk ends with its first vertex.
+// Returns nil if two consecutive vertices are not connected.
+func ExpandWalk(graph Graph, prev [][]int, tour []graphLib.VertexInterface) []graphLib.VertexInterface {
+    if len(tour) == 0 {
+        return nil
+    }
+    vertices := graph.GetVertices()
+    walk := []graphLib.VertexInterface{vertices.GetPos(tour[0].GetPos())}
+    for i := range tour {
+        s, t := tour[i].GetPos(), tour[(i + 1) % len(tour)].GetPos()
+
+        // the shortest path is built backwards
+        path := []int{}
+        for v := t; v != s; v = prev[s][v] {
+            if v < 0 {
+                return nil
+            }
+            path = append(path, v)
+        }
+        for j := len(path) - 1; j >= 0; j-- {
+            walk = append(walk, vertices.GetPos(path[j]))
+        }
+    }
+    return walk
+}
+
+// returns the lengths of the shortest paths between all vertices and the previous vertex on each of them
+// Both are indexed by the positions of the start and end vertex. Unreachable vertices have an infinite
+// distance and -1 as previous vertex. Dijkstra is run from every vertex, so negative weights are not allowed.
+func shortestPathMatrix(graph Graph) ([][]float64, [][]int, error) {
+    if err := checkNonNegativeWeights(graph); err != nil {
+        return nil, nil, err
+    }
+
+    num := int(graph.GetVertices().Count())
+    distances, prev := make([][]float64, num), make([][]int, num)
+    for s := 0; s < num; s++ {
+        distances[s], prev[s] = dijkstra(graph, []int{s})
+    }
+
+    return distances, prev, nil
+}
+
+// returns an error if there is an edge with a negative weight
+func checkNonNegativeWeights(graph Graph) error {
+    for _, edge := range graph.GetEdges().All() {
+        if edge.GetWeight() < 0 {
+            return errors.New("Negative edge weight found.")
+        }
+    }
+    return nil
+}
+
+// returns the lengths of the shortest paths from the nearest of the sources to all vertices and the previous
+// vertex on each of them, indexed by positions. Sources and unreachable vertices have -1 as previous vertex,
+// unreachable ones also have an infinite distance. The weights must not be negative.
+func dijkstra(graph Graph, sources []int) ([]float64, []int) {
+    num := int(graph.GetVertices().Count())
+
+    // queue
+    q := make(shortestPathQueue, num)
+    // map positions to the objects that are used here
+    m := make([]*shortestPathVertex, num)
+    for i, v := range graph.GetVertices().All() {
+        m[v.GetPos()] = &shortestPathVertex{
+            VertexInterface: v,
+            prev: nil,
+            distance: math.Inf(1),
+            index: i,
+        }
+        q[i] = m[v.GetPos()]
+    }
+    for _, s := range sources {
+        m[s].prev = m[s]
+        m[s].distance = 0
+    }
+    q.init()
+
+    // take each item
+    for q.Len() > 0 {
+        current := q.popNearest()
+
+        // the remaining vertices are not reachable
+        if current.prev == nil {
+            break
+        }
+
+        // go through edges
+        for _, edge := range graph.getEdgesOfVertex(current.VertexInterface).All() {
+            neighbour := m[edge.GetOtherVertex(current.VertexInterface).GetPos()]
+            if distance := current.distance + edge.GetWeight(); distance < neighbour.distance {
+                // shorter path found
+                q.update(neighbour, current, distance)
+            }
+        }
+    }
+
+    distances, prev := make([]float64, num), make([]int, num)
+    for v, item := range m {
+        distances[v], prev[v] = item.distance, -1
+        if item.prev != nil && item.prev != item {
+            prev[v] = item.prev.GetPos()
+        }
+    }
+    return distances, prev
+}
@@ -0,0 +1,72 @@
+package algorithm
+
+import (
+    "testing"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the metric closure and the expansion of tours on a sparse graph
+func TestMetricClosure(t *testing.T) {
+
+    // two triangles connected by a single edge
+    // (0)-(1)   (4)
+    //   \ /     / \
+    //   (2)---(3)-(5)
+    g, err := parser.ParseEdges(strings.NewReader("6 0 1 1 1 2 1 2 0 1 2 3 5 3 4 1 4 5 1 5 3 1"), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+
+    closure, prev, err := graph.MetricClosure()
+    if err != nil {
+        t.Fatalf("Expected no error, got \"%s\".", err.Error())
+    }
+    if n := closure.GetEdges().Count(); n != 15 {
+        t.Errorf("Expected 15 edges in the metric closure, got %d.", n)
+    }
+    weights := closure.getWeightMatrix()
+    if w := weights[0][4]; w != 7 {
+        t.Errorf("Expected distance 7 from 0 to 4, got %f.", w)
+    }
+
+    // the heuristics must work on the closure and the walks must exist in the graph
+    start := closure.GetVertices().Get(0)
+    tour, length, err := closure.DoubleTreeHamiltonCircle(Prim, start)
+    if err != nil {
+        t.Fatal(err)
+    }
+    validateTour(t, closure, tour)
+    if length != 16 {
+        t.Errorf("Expected length 16, got %f.", length)
+    }
+
+    walk := graph.ExpandWalk(prev, tour)
+    if len(walk) < len(tour) + 1 || walk[0] != walk[len(walk) - 1] {
+        t.Fatalf("Expected a closed walk, got %v.", walk)
+    }
+    walkLength := 0.0
+    for i := 1; i < len(walk); i++ {
+        w := graph.getWeightBetween(walk[i - 1], walk[i])
+        if w < 0 {
+            t.Errorf("Expected an edge from %d to %d.", walk[i - 1].GetId(), walk[i].GetId())
+        }
+        walkLength += w
+    }
+    if walkLength != length {
+        t.Errorf("Expected walk of length %f, got %f.", length, walkLength)
+    }
+}
+
+// test that negative weights are rejected
+func TestMetricClosureNegative(t *testing.T) {
+    g, err := parser.ParseEdges(strings.NewReader("3 0 1 1 1 2 -1"), true)
+    if err != nil {
+        panic(err)
+    }
+    if _, _, err := (Graph{g}).MetricClosure(); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}
@@ -2,6 +2,9 @@ package algorithm
 
 import (
     "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "errors"
+    "fmt"
 )
 
 type Graph struct {
@@ -36,6 +39,61 @@ func (this Graph) getWeightBetween(v1, v2 graph.VertexInterface) float64 {
     return -1
 }
 
+// returns the weights between all vertices, indexed by their positions
+// Missing edges have an infinite weight. If there are multiple edges, the lightest one is used.
+// The direction of the edges is ignored, see getDistanceMatrix for directed graphs.
+func (this Graph) getWeightMatrix() [][]float64 {
+    return this.weightMatrix(false)
+}
+
+// returns the distances from each vertex to each other vertex, indexed by their positions
+// Unlike getWeightMatrix the edges of directed graphs are only used in their direction.
+func (this Graph) getDistanceMatrix() [][]float64 {
+    return this.weightMatrix(this.IsDirected())
+}
+
+// returns the distances like getDistanceMatrix, but fails if they are not the same in both directions
+// Algorithms that reverse parts of their circles or trees need this, the name of the algorithm is part of the error.
+func (this Graph) getSymmetricDistanceMatrix(name string) ([][]float64, error) {
+    weights := this.getDistanceMatrix()
+    if !this.IsDirected() {
+        return weights, nil
+    }
+    for s := range weights {
+        for t := s + 1; t < len(weights); t++ {
+            if weights[s][t] != weights[t][s] {
+                vertices := this.GetVertices()
+                return nil, errors.New(fmt.Sprintf("%s needs the same distances in both directions, but they differ between vertices %d and %d.", name, vertices.GetPos(s).GetId(), vertices.GetPos(t).GetId()))
+            }
+        }
+    }
+    return weights, nil
+}
+
+// helper for getWeightMatrix and getDistanceMatrix
+func (this Graph) weightMatrix(directed bool) [][]float64 {
+    num := int(this.GetVertices().Count())
+    weights := make([][]float64, num)
+    for i := range weights {
+        weights[i] = make([]float64, num)
+        for j := range weights[i] {
+            if i != j {
+                weights[i][j] = math.Inf(1)
+            }
+        }
+    }
+    for _, edge := range this.GetEdges().All() {
+        s, t, w := edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos(), edge.GetWeight()
+        if s != t && w < weights[s][t] {
+            weights[s][t] = w
+        }
+        if s != t && !directed && w < weights[t][s] {
+            weights[t][s] = w
+        }
+    }
+    return weights
+}
+
 // returns the edge from the one to the other of the two given vertices
 func (this Graph) getEdgeFromTo(v1, v2 graph.VertexInterface) graph.EdgeInterface {
     for _, edge := range v1.GetOutgoingEdges().All() {
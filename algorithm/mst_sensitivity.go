@@ -0,0 +1,264 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "sort"
+    "errors"
+)
+
+// the range of weights of an edge for which the minimal spanning tree stays minimal
+// Tree edges can get heavier up to Upper, the other edges can get lighter down to Lower.
+type EdgeSensitivity struct {
+    Edge   graphLib.EdgeInterface
+    InTree bool
+    Lower  float64
+    Upper  float64
+}
+
+// simple wrapper
+func (this Graph) MinimalSpanningTreeSensitivity(start graphLib.VertexInterface) ([]EdgeSensitivity, error) {
+    return MinimalSpanningTreeSensitivity(this, start)
+}
+
+// returns for each edge the range of weights for which the minimal spanning tree found by Prim stays minimal
+// An edge of the tree may get as heavy as the lightest other edge that can replace it, an edge that is not part of the
+// tree may get as light as the heaviest edge on the path of the tree between its vertices. Edges that can not be
+// replaced have an infinite upper bound. The direction of the edges is ignored, the graph has to be connected.
+func MinimalSpanningTreeSensitivity(graph Graph, start graphLib.VertexInterface) ([]EdgeSensitivity, error) {
+    tree, err := newSpanningTreePaths(graph, start)
+    if err != nil {
+        return nil, err
+    }
+    edges := tree.edges
+
+    /*
+     * 1. Edges outside of the tree are limited by the heaviest edge on the path of the tree.
+     */
+    result := make([]EdgeSensitivity, len(edges))
+    outside := make([]int, 0, len(edges))
+    weights := make([]float64, len(edges))
+    for i, edge := range edges {
+        result[i] = EdgeSensitivity{Edge: edge, InTree: tree.inTree[i], Lower: math.Inf(-1), Upper: math.Inf(1)}
+        weights[i] = edge.GetWeight()
+        if tree.inTree[i] {
+            continue
+        }
+        if u, v := tree.ends(i); u != v {
+            result[i].Lower = edges[tree.heaviestOnPath(u, v)].GetWeight()
+            outside = append(outside, i)
+        }
+    }
+
+    /*
+     * 2. Tree edges are limited by the lightest edge outside of the tree whose path contains them.
+     *    The outside edges are processed from light to heavy, so each tree edge only gets the first one.
+     *    Tree edges that already got their limit are skipped by jumping to the highest vertex below them.
+     */
+    sort.Sort(byWeightFrom{outside, weights})
+    jump := make([]int, len(tree.depth))
+    for v := range jump {
+        jump[v] = v
+    }
+    var find func(v int) int
+    find = func(v int) int {
+        if jump[v] != v {
+            jump[v] = find(jump[v])
+        }
+        return jump[v]
+    }
+    for _, i := range outside {
+        u, v := tree.ends(i)
+        for u, v = find(u), find(v); u != v; u = find(u) {
+            if tree.depth[u] < tree.depth[v] {
+                u, v = v, u
+            }
+            result[tree.parentEdge[u]].Upper = weights[i]
+            jump[u] = tree.up[0][u]
+        }
+    }
+
+    return result, nil
+}
+
+// simple wrapper
+func (this Graph) SecondBestMinimalSpanningTree(start graphLib.VertexInterface) (float64, []graphLib.EdgeInterface, error) {
+    return SecondBestMinimalSpanningTree(this, start)
+}
+
+// returns the weight and the edges of the lightest spanning tree that differs from the one found by Prim
+// It replaces one edge of the tree by another one, where the difference of their weights is minimal.
+// The direction of the edges is ignored, the graph has to be connected.
+func SecondBestMinimalSpanningTree(graph Graph, start graphLib.VertexInterface) (float64, []graphLib.EdgeInterface, error) {
+    tree, err := newSpanningTreePaths(graph, start)
+    if err != nil {
+        return 0, nil, err
+    }
+
+    // find the cheapest exchange of an edge outside of the tree with the heaviest one on its path
+    add, remove, best := -1, -1, math.Inf(1)
+    for i, edge := range tree.edges {
+        if tree.inTree[i] {
+            continue
+        }
+        if u, v := tree.ends(i); u != v {
+            h := tree.heaviestOnPath(u, v)
+            if d := edge.GetWeight() - tree.edges[h].GetWeight(); d < best {
+                add, remove, best = i, h, d
+            }
+        }
+    }
+    if add < 0 {
+        return 0, nil, errors.New("The graph has no other spanning tree.")
+    }
+
+    weight, result := 0.0, make([]graphLib.EdgeInterface, 0, len(tree.depth) - 1)
+    for i, edge := range tree.edges {
+        if tree.inTree[i] && i != remove || i == add {
+            weight += edge.GetWeight()
+            result = append(result, edge)
+        }
+    }
+    return weight, result, nil
+}
+
+// the minimal spanning tree found by Prim, prepared for queries of the heaviest edge on the path between two vertices
+// The tree is rooted at the start vertex. Each vertex knows its ancestors 2^k levels above and the heaviest edge on
+// the way there (binary lifting). Vertices and edges are given by their positions in the graph.
+type spanningTreePaths struct {
+    edges  []graphLib.EdgeInterface
+    inTree []bool
+
+    // the depth, the edge to the parent, the ancestors and the heaviest edges on the way to them
+    depth      []int
+    parentEdge []int
+    up         [][]int
+    heaviest   [][]int
+}
+
+// builds the minimal spanning tree and the tables for the queries
+func newSpanningTreePaths(graph Graph, start graphLib.VertexInterface) (*spanningTreePaths, error) {
+    num := int(graph.GetVertices().Count())
+    _, mst, vMap := Prim(graph, start)
+    if int(mst.GetEdges().Count()) != num - 1 {
+        return nil, errors.New("The graph is not connected.")
+    }
+    t := &spanningTreePaths{
+        edges: graph.GetEdges().All(),
+        depth: make([]int, num),
+        parentEdge: make([]int, num),
+    }
+    t.inTree = make([]bool, len(t.edges))
+
+    /*
+     * 1. Find the edges of the graph that belong to the tree. Prim creates new edges with the same weights.
+     */
+    original := make(map[graphLib.VertexInterface]graphLib.VertexInterface, num)
+    for v, m := range vMap {
+        original[m] = v
+    }
+    treeEdges := make(map[[2]int]float64, num)
+    for _, edge := range mst.GetEdges().All() {
+        a, b := original[edge.GetStartVertex()].GetPos(), original[edge.GetEndVertex()].GetPos()
+        if a > b {
+            a, b = b, a
+        }
+        treeEdges[[2]int{a, b}] = edge.GetWeight()
+    }
+    neighbours := make([][]int, num)
+    for i, edge := range t.edges {
+        a, b := t.ends(i)
+        if a > b {
+            a, b = b, a
+        }
+        if w, ok := treeEdges[[2]int{a, b}]; ok && w == edge.GetWeight() {
+            // parallel edges of the same weight are only used once
+            delete(treeEdges, [2]int{a, b})
+            t.inTree[i] = true
+            neighbours[a] = append(neighbours[a], i)
+            neighbours[b] = append(neighbours[b], i)
+        }
+    }
+
+    /*
+     * 2. Root the tree and fill the tables.
+     */
+    levels := 1
+    for 1 << uint(levels) < num {
+        levels++
+    }
+    t.up, t.heaviest = make([][]int, levels), make([][]int, levels)
+    for k := range t.up {
+        t.up[k], t.heaviest[k] = make([]int, num), make([]int, num)
+    }
+    root := start.GetPos()
+    t.up[0][root], t.parentEdge[root], t.heaviest[0][root] = root, -1, -1
+    queue, visited := []int{root}, make([]bool, num)
+    visited[root] = true
+    for len(queue) > 0 {
+        v := queue[0]
+        queue = queue[1:]
+        for _, i := range neighbours[v] {
+            a, b := t.ends(i)
+            w := a
+            if w == v {
+                w = b
+            }
+            if !visited[w] {
+                visited[w] = true
+                t.depth[w], t.parentEdge[w], t.up[0][w], t.heaviest[0][w] = t.depth[v] + 1, i, v, i
+                queue = append(queue, w)
+            }
+        }
+    }
+    for k := 1; k < levels; k++ {
+        for v := 0; v < num; v++ {
+            mid := t.up[k - 1][v]
+            t.up[k][v] = t.up[k - 1][mid]
+            t.heaviest[k][v] = t.heavier(t.heaviest[k - 1][v], t.heaviest[k - 1][mid])
+        }
+    }
+
+    return t, nil
+}
+
+// returns the positions of the vertices of the edge
+func (this *spanningTreePaths) ends(i int) (int, int) {
+    return this.edges[i].GetStartVertex().GetPos(), this.edges[i].GetEndVertex().GetPos()
+}
+
+// returns the heavier one of two edges, -1 is no edge
+func (this *spanningTreePaths) heavier(a, b int) int {
+    if a < 0 || b >= 0 && this.edges[b].GetWeight() > this.edges[a].GetWeight() {
+        return b
+    }
+    return a
+}
+
+// returns the heaviest edge on the path of the tree between u and v, -1 if u and v are the same
+func (this *spanningTreePaths) heaviestOnPath(u, v int) int {
+    result := -1
+    if this.depth[u] < this.depth[v] {
+        u, v = v, u
+    }
+
+    // lift u to the depth of v
+    for k := len(this.up) - 1; k >= 0; k-- {
+        if this.depth[u] - 1 << uint(k) >= this.depth[v] {
+            result = this.heavier(result, this.heaviest[k][u])
+            u = this.up[k][u]
+        }
+    }
+
+    // lift both to just below their lowest common ancestor
+    for k := len(this.up) - 1; k >= 0 && u != v; k-- {
+        if this.up[k][u] != this.up[k][v] {
+            result = this.heavier(result, this.heavier(this.heaviest[k][u], this.heaviest[k][v]))
+            u, v = this.up[k][u], this.up[k][v]
+        }
+    }
+    if u != v {
+        result = this.heavier(result, this.heavier(this.heaviest[0][u], this.heaviest[0][v]))
+    }
+    return result
+}
@@ -0,0 +1,137 @@
+package algorithm
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+    "sort"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// returns a random connected graph and the ends and weights of its edges
+func randomConnectedGraph(r *rand.Rand, num, extra int) (Graph, [][3]float64) {
+    input := fmt.Sprintln(num)
+    for v := 1; v < num; v++ {
+        input += fmt.Sprintln(r.Intn(v), v, 1 + r.Intn(9))
+    }
+    for i := 0; i < extra; i++ {
+        input += fmt.Sprintln(r.Intn(num), r.Intn(num), 1 + r.Intn(9))
+    }
+    g, err := parser.ParseEdges(strings.NewReader(input), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    edges := make([][3]float64, 0)
+    for _, e := range graph.GetEdges().All() {
+        edges = append(edges, [3]float64{float64(e.GetStartVertex().GetPos()), float64(e.GetEndVertex().GetPos()), e.GetWeight()})
+    }
+    return graph, edges
+}
+
+// returns the weight of the minimal spanning tree of the edges
+func minimalSpanningTreeWeight(num int, edges [][3]float64) float64 {
+    order := make([]int, len(edges))
+    weights := make([]float64, len(edges))
+    for i := range order {
+        order[i], weights[i] = i, edges[i][2]
+    }
+    sort.Sort(byWeightFrom{order, weights})
+    components, weight := newUnionFind(num), 0.0
+    for _, i := range order {
+        if components.union(int(edges[i][0]), int(edges[i][1])) {
+            weight += edges[i][2]
+        }
+    }
+    return weight
+}
+
+// test the weight ranges by changing the weights just inside and outside of them
+func TestMinimalSpanningTreeSensitivity(t *testing.T) {
+    r := rand.New(rand.NewSource(1))
+    for n := 0; n < 100; n++ {
+        num := 2 + r.Intn(8)
+        graph, edges := randomConnectedGraph(r, num, r.Intn(10))
+        weight := minimalSpanningTreeWeight(num, edges)
+
+        result, err := graph.MinimalSpanningTreeSensitivity(graph.GetVertices().Get(0))
+        if err != nil {
+            t.Fatal(err)
+        }
+        for i, s := range result {
+            w := edges[i][2]
+            changed := func(x float64) float64 {
+                edges[i][2] = x
+                defer func() { edges[i][2] = w }()
+                return minimalSpanningTreeWeight(num, edges)
+            }
+            if s.InTree {
+                // the tree gets heavier by the change until another edge is lighter
+                if limit := math.Min(s.Upper, w + 100); changed(limit - 0.5) != weight + limit - 0.5 - w {
+                    t.Errorf("Expected tree edge %d to stay in the tree up to %f.", i, s.Upper)
+                }
+                if !math.IsInf(s.Upper, 1) && changed(s.Upper + 0.5) >= weight + s.Upper + 0.5 - w {
+                    t.Errorf("Expected tree edge %d to be replaced above %f.", i, s.Upper)
+                }
+            } else if edges[i][0] != edges[i][1] {
+                // the tree stays the same until the edge is lighter than the heaviest one on the path
+                if changed(s.Lower + 0.5) != weight || changed(s.Lower - 0.5) != weight - 0.5 {
+                    t.Errorf("Expected edge %d to be used below %f.", i, s.Lower)
+                }
+            }
+        }
+    }
+}
+
+// test the second-best spanning tree against all spanning trees
+func TestSecondBestMinimalSpanningTree(t *testing.T) {
+    r := rand.New(rand.NewSource(2))
+    for n := 0; n < 100; n++ {
+        num := 2 + r.Intn(5)
+        graph, edges := randomConnectedGraph(r, num, r.Intn(5))
+        start := graph.GetVertices().Get(0)
+
+        weight, tree, err := graph.SecondBestMinimalSpanningTree(start)
+
+        // try every subset of edges, the trees differ from the minimal one in at least one edge
+        tree0, _ := newSpanningTreePaths(graph, start)
+        expected := math.Inf(1)
+        for set := 0; set < 1 << uint(len(edges)); set++ {
+            components, w, count, same := newUnionFind(num), 0.0, 0, true
+            for i := range edges {
+                if set & (1 << uint(i)) != 0 && components.union(int(edges[i][0]), int(edges[i][1])) {
+                    w, count = w + edges[i][2], count + 1
+                }
+                same = same && (set & (1 << uint(i)) != 0) == tree0.inTree[i]
+            }
+            if count == num - 1 && bitCount(set) == num - 1 && !same {
+                expected = math.Min(expected, w)
+            }
+        }
+
+        if math.IsInf(expected, 1) {
+            if err == nil {
+                t.Errorf("Expected error, got tree of weight %f.", weight)
+            }
+            continue
+        }
+        if err != nil {
+            t.Fatal(err)
+        }
+        if weight != expected || len(tree) != num - 1 {
+            t.Errorf("Expected weight %f with %d edges, got %f with %d.", expected, num - 1, weight, len(tree))
+        }
+    }
+}
+
+// returns the number of set bits
+func bitCount(x int) int {
+    count := 0
+    for ; x > 0; x &= x - 1 {
+        count++
+    }
+    return count
+}
@@ -5,12 +5,13 @@ import (
 )
 
 // simple wrapper
-func (this Graph) NearestNeighbourHamiltonCircleLength(start graphLib.VertexInterface) float64 {
-    return NearestNeighbourHamiltonCircleLength(this, start)
+func (this Graph) NearestNeighbourHamiltonCircle(start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
+    return NearestNeighbourHamiltonCircle(this, start)
 }
 
-// returns the length of the hamilton circle calculated by the nearest neighbour algorithm
-func NearestNeighbourHamiltonCircleLength(graph Graph, start graphLib.VertexInterface) float64 {
+// returns the hamilton circle calculated by the nearest neighbour algorithm and its length
+// The length is -1 if there is no edge back to the start vertex. Directed graphs only use edges in their direction.
+func NearestNeighbourHamiltonCircle(graph Graph, start graphLib.VertexInterface) ([]graphLib.VertexInterface, float64) {
 
     // keep track of which vertices we already visited
     visited := map[graphLib.VertexInterface]bool{start: true}
@@ -18,21 +19,28 @@ func NearestNeighbourHamiltonCircleLength(graph Graph, start graphLib.VertexInte
     // keep track of the total length
     length := 0.0
 
-    // the current vertex
+    // the current vertex and the order of the visited vertices
     vertex := start
+    tour := make([]graphLib.VertexInterface, 1, graph.GetVertices().Count())
+    tour[0] = start
 
     // (number of vertices) - 1 iterations are needed
     for n := graph.GetVertices().Count(); n > 1; n-- {
 
         // find the nearest neighbour, that is not visited yet
         weight, next := 0.0, graphLib.VertexInterface(nil)
-        for _, edge := range vertex.GetEdges().All() {
+        for _, edge := range graph.getEdgesOfVertex(vertex).All() {
             w, v := edge.GetWeight(), edge.GetOtherVertex(vertex)
             if (next == nil || w < weight) && !visited[v] {
                 weight, next = w, v
             }
         }
 
+        // stuck because all neighbours are visited already
+        if next == nil {
+            return tour, -1
+        }
+
         // add the distance to the nearest neighbour
         length += weight
 
@@ -41,14 +49,42 @@ func NearestNeighbourHamiltonCircleLength(graph Graph, start graphLib.VertexInte
 
         // continue with nearest neighbour
         vertex = next
+        tour = append(tour, next)
 
     }
 
     // add the return path
-    if w := graph.getWeightBetween(vertex, start); w >= 0.0 {
-        return length + w
+    if graph.IsDirected() {
+        if edge := graph.getEdgeFromTo(vertex, start); edge != nil {
+            return tour, length + edge.GetWeight()
+        }
+    } else if w := graph.getWeightBetween(vertex, start); w >= 0.0 {
+        return tour, length + w
     }
 
     // if none was found
-    return -1
-}
\ No newline at end of file
+    return tour, -1
+}
+
+// returns the tour calculated by the nearest neighbour algorithm as vertex positions
+func nearestNeighbourTour(weights [][]float64, start int) []int {
+    num := len(weights)
+    tour, visited := make([]int, 1, num), make([]bool, num)
+    tour[0], visited[start] = start, true
+    for v := start; len(tour) < num; {
+
+        // find the nearest neighbour, that is not visited yet
+        next := -1
+        for w := 0; w < num; w++ {
+            if !visited[w] && (next < 0 || weights[v][w] < weights[v][next]) {
+                next = w
+            }
+        }
+
+        // continue with nearest neighbour
+        visited[next] = true
+        tour = append(tour, next)
+        v = next
+    }
+    return tour
+}
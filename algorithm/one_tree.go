@@ -0,0 +1,448 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "errors"
+)
+
+// the number of subgradient iterations at the root and at every other node of the search tree
+const (
+    oneTreeRootIterations = 1000
+    oneTreeNodeIterations = 50
+)
+
+// the tolerance used when comparing bounds
+const oneTreeEpsilon = 1e-9
+
+// simple wrapper
+func (this Graph) TravelingSalesmanOneTree() ([]graphLib.VertexInterface, float64, error) {
+    return TravelingSalesmanOneTree(this)
+}
+
+// returns the shortest hamilton circle and its length using branch and bound with 1-tree lower bounds
+// The lower bounds are raised by the subgradient optimization of Held and Karp. The first upper bound
+// is the Lin-Kernighan circle. Instances with 40 to 60 vertices can usually be solved. The circle starts with the first vertex.
+// 1-trees have no direction, so directed graphs need the same distances in both directions.
+func TravelingSalesmanOneTree(graph Graph) ([]graphLib.VertexInterface, float64, error) {
+
+    num := int(graph.GetVertices().Count())
+    if num < 3 {
+        return nil, 0, errors.New("The 1-tree branch and bound needs at least 3 vertices.")
+    }
+    weights, err := graph.getSymmetricDistanceMatrix("The 1-tree branch and bound")
+    if err != nil {
+        return nil, 0, err
+    }
+
+    solver := newOneTreeSolver(weights)
+    tour, length := solver.solve()
+    if tour == nil {
+        return nil, 0, errors.New("No hamilton circle was found.")
+    }
+    return graph.tourVertices(tour), length, nil
+}
+
+// simple wrapper
+func (this Graph) OneTreeLowerBound() float64 {
+    return OneTreeLowerBound(this)
+}
+
+// returns the Held-Karp lower bound of the length of every hamilton circle
+// It is the best 1-tree found by subgradient optimization at the root of the 1-tree branch and bound.
+// The direction of edges is ignored and the lighter one of both directions is used, so on directed graphs it is
+// a bound of the symmetric relaxation, which is still below every directed circle. The bound is infinite if there is
+// no hamilton circle.
+func OneTreeLowerBound(graph Graph) float64 {
+    num := int(graph.GetVertices().Count())
+    if num < 3 {
+        return 0
+    }
+    solver := newOneTreeSolver(graph.getWeightMatrix())
+    root := solver.root()
+    if !solver.propagate(root.status) {
+        return math.Inf(1)
+    }
+    _, bound, _ := solver.bound(root, oneTreeRootIterations)
+    return bound
+}
+
+// a node of the search tree
+// The status of an edge is 1 if it must be part of the circle, -1 if it must not and 0 otherwise.
+type oneTreeNode struct {
+    status [][]int8
+    pi     []float64
+}
+
+// helper for the 1-tree branch and bound
+type oneTreeSolver struct {
+    weights [][]float64
+    num     int
+
+    // the best known circle
+    tour       []int
+    upperBound float64
+}
+
+// inits the solver with the Lin-Kernighan circle as the first upper bound
+func newOneTreeSolver(weights [][]float64) *oneTreeSolver {
+    this := &oneTreeSolver{
+        weights: weights,
+        num: len(weights),
+    }
+    this.tour = linKernighanTour(weights, nearestNeighbourTour(weights, 0))
+    this.upperBound = tourLength(weights, this.tour)
+
+    // without a circle use a bound that every circle satisfies
+    if math.IsInf(this.upperBound, 1) {
+        this.tour, this.upperBound = nil, 1.0
+        for _, row := range weights {
+            max := 0.0
+            for _, w := range row {
+                if !math.IsInf(w, 1) {
+                    max = math.Max(max, math.Abs(w))
+                }
+            }
+            this.upperBound += max
+        }
+    }
+    return this
+}
+
+// searches the tree depth-first and returns the best circle
+func (this *oneTreeSolver) solve() ([]int, float64) {
+
+    root := this.root()
+    if !this.propagate(root.status) {
+        return nil, 0
+    }
+
+    stack := []*oneTreeNode{root}
+    for iterations := oneTreeRootIterations; len(stack) > 0; iterations = oneTreeNodeIterations {
+        node := stack[len(stack) - 1]
+        stack = stack[:len(stack) - 1]
+
+        // prune if the bound is not better than the best known circle
+        tree, _, solved := this.bound(node, iterations)
+        if tree == nil || solved {
+            continue
+        }
+
+        // branch on an edge of the 1-tree: first without, then with the edge
+        u, v := this.branchingEdge(node, tree)
+        for _, status := range [2]int8{1, -1} {
+            child := &oneTreeNode{
+                status: make([][]int8, this.num),
+                pi: append([]float64{}, node.pi...),
+            }
+            for i := range child.status {
+                child.status[i] = append([]int8{}, node.status[i]...)
+            }
+            child.status[u][v], child.status[v][u] = status, status
+            if this.propagate(child.status) {
+                stack = append(stack, child)
+            }
+        }
+    }
+
+    return this.tour, this.upperBound
+}
+
+// returns the root of the search tree, which has no constraints but the missing edges
+func (this *oneTreeSolver) root() *oneTreeNode {
+    root := &oneTreeNode{
+        status: make([][]int8, this.num),
+        pi: make([]float64, this.num),
+    }
+    for i := range root.status {
+        root.status[i] = make([]int8, this.num)
+        for j := range root.status[i] {
+            if i == j || math.IsInf(this.weights[i][j], 1) {
+                root.status[i][j] = -1
+            }
+        }
+    }
+    return root
+}
+
+// raises the lower bound of the node by subgradient optimization
+// Returns the best 1-tree and its bound or nil if the node can be pruned. If the 1-tree is a circle, solved is true.
+// The penalties of the node are replaced by the best ones found.
+func (this *oneTreeSolver) bound(node *oneTreeNode, iterations int) ([][2]int, float64, bool) {
+    pi := append([]float64{}, node.pi...)
+    bestBound, bestTree := math.Inf(-1), [][2]int(nil)
+    lambda, period, sincePeriod, improved := 2.0, iterations / 10 + 1, 0, false
+
+    for i := 0; i < iterations && lambda > 1e-4; i++ {
+        cost, degrees, tree := this.oneTree(node.status, pi)
+        if tree == nil {
+            return nil, math.Inf(1), false
+        }
+
+        // the lower bound
+        bound := cost
+        for _, p := range pi {
+            bound -= 2 * p
+        }
+        if bound > bestBound + oneTreeEpsilon {
+            bestBound, bestTree, improved = bound, tree, true
+            copy(node.pi, pi)
+        }
+        if bestBound >= this.upperBound - oneTreeEpsilon {
+            return nil, bestBound, false
+        }
+
+        // the subgradient is the deviation from degree 2
+        norm := 0
+        for _, d := range degrees {
+            norm += (d - 2) * (d - 2)
+        }
+        if norm == 0 {
+            // the 1-tree is a circle and therefore the best one of this node
+            this.tour, this.upperBound = this.treeToTour(tree), bound
+            return tree, bound, true
+        }
+
+        // move the penalties
+        step := lambda * (this.upperBound - bound) / float64(norm)
+        for v, d := range degrees {
+            pi[v] += step * float64(d - 2)
+        }
+
+        // halve the step size if the bound did not improve for a while
+        if sincePeriod++; sincePeriod >= period {
+            if !improved {
+                lambda /= 2
+            }
+            sincePeriod, improved = 0, false
+        }
+    }
+
+    return bestTree, bestBound, false
+}
+
+// returns the minimal 1-tree under the constraints using the costs w[i][j] + pi[i] + pi[j]
+// The 1-tree consists of a spanning tree of all vertices but 0 and the two cheapest edges at vertex 0.
+// Edges that must be part of the circle are always preferred. Returns a nil tree if there is none.
+func (this *oneTreeSolver) oneTree(status [][]int8, pi []float64) (float64, []int, [][2]int) {
+    num, w := this.num, this.weights
+    cost := func(i, j int) float64 {
+        return w[i][j] + pi[i] + pi[j]
+    }
+    // returns whether the edge i-j is better than the one with the given cost and force
+    better := func(i, j int, c float64, forced bool) bool {
+        f := status[i][j] == 1
+        return f && !forced || f == forced && cost(i, j) < c
+    }
+
+    tree, degrees, total := make([][2]int, 0, num), make([]int, num), 0.0
+    add := func(i, j int) {
+        tree = append(tree, [2]int{i, j})
+        degrees[i]++
+        degrees[j]++
+        total += cost(i, j)
+    }
+
+    // Prim on the vertices 1..n-1
+    inTree, key, forced, parent := make([]bool, num), make([]float64, num), make([]bool, num), make([]int, num)
+    for v := range key {
+        key[v], parent[v] = math.Inf(1), -1
+    }
+    for v := 1; v < num; v++ {
+        if v != 1 && status[1][v] != -1 {
+            key[v], forced[v], parent[v] = cost(1, v), status[1][v] == 1, 1
+        }
+    }
+    inTree[1] = true
+    for n := 2; n < num; n++ {
+        next := -1
+        for v := 2; v < num; v++ {
+            if !inTree[v] && parent[v] >= 0 && (next < 0 || forced[v] && !forced[next] || forced[v] == forced[next] && key[v] < key[next]) {
+                next = v
+            }
+        }
+        if next < 0 {
+            // not connected
+            return 0, nil, nil
+        }
+        inTree[next] = true
+        add(parent[next], next)
+        for v := 2; v < num; v++ {
+            if !inTree[v] && status[next][v] != -1 && (parent[v] < 0 || better(next, v, key[v], forced[v])) {
+                key[v], forced[v], parent[v] = cost(next, v), status[next][v] == 1, next
+            }
+        }
+    }
+
+    // the two best edges at vertex 0
+    first, second := -1, -1
+    for v := 1; v < num; v++ {
+        if status[0][v] == -1 {
+            continue
+        }
+        if first < 0 || better(0, v, cost(0, first), status[0][first] == 1) {
+            first, second = v, first
+        } else if second < 0 || better(0, v, cost(0, second), status[0][second] == 1) {
+            second = v
+        }
+    }
+    if second < 0 {
+        return 0, nil, nil
+    }
+    add(0, first)
+    add(0, second)
+
+    return total, degrees, tree
+}
+
+// chooses a free edge of the 1-tree at the vertex with the highest degree
+// Among those the most expensive one is taken.
+func (this *oneTreeSolver) branchingEdge(node *oneTreeNode, tree [][2]int) (int, int) {
+    degrees := make([]int, this.num)
+    for _, e := range tree {
+        degrees[e[0]]++
+        degrees[e[1]]++
+    }
+
+    bestU, bestV, bestDegree, bestCost := -1, -1, 0, 0.0
+    for _, e := range tree {
+        if node.status[e[0]][e[1]] != 0 {
+            continue
+        }
+        c := this.weights[e[0]][e[1]] + node.pi[e[0]] + node.pi[e[1]]
+        d := degrees[e[0]]
+        if degrees[e[1]] > d {
+            d = degrees[e[1]]
+        }
+        if bestU < 0 || d > bestDegree || d == bestDegree && c > bestCost {
+            bestU, bestV, bestDegree, bestCost = e[0], e[1], d, c
+        }
+    }
+    return bestU, bestV
+}
+
+// applies the degree and subtour constraints until nothing changes
+// Returns false if the constraints cannot be satisfied.
+func (this *oneTreeSolver) propagate(status [][]int8) bool {
+    num := this.num
+    for changed := true; changed; {
+        changed = false
+
+        // degree constraints: every vertex has exactly two edges in the circle
+        for v := 0; v < num; v++ {
+            included, available := 0, 0
+            for u := 0; u < num; u++ {
+                if status[v][u] == 1 {
+                    included++
+                }
+                if status[v][u] != -1 {
+                    available++
+                }
+            }
+            if included > 2 || available < 2 {
+                return false
+            }
+            if included == 2 && available > 2 {
+                // all other edges are not needed
+                for u := 0; u < num; u++ {
+                    if status[v][u] == 0 {
+                        status[v][u], status[u][v] = -1, -1
+                    }
+                }
+                changed = true
+            } else if available == 2 && included < 2 {
+                // all remaining edges are needed
+                for u := 0; u < num; u++ {
+                    if status[v][u] == 0 {
+                        status[v][u], status[u][v] = 1, 1
+                    }
+                }
+                changed = true
+            }
+        }
+
+        if changed {
+            continue
+        }
+
+        // subtour constraints: the included edges must not close a circle too early
+        included := make([]int, num)
+        for v := 0; v < num; v++ {
+            for u := 0; u < num; u++ {
+                if status[v][u] == 1 {
+                    included[v]++
+                }
+            }
+        }
+        visited := make([]bool, num)
+        for v := 0; v < num; v++ {
+            if visited[v] || included[v] != 1 {
+                continue
+            }
+
+            // walk the path of included edges to its other end
+            size, prev, cur := 1, -1, v
+            visited[v] = true
+            for next := v; next >= 0; {
+                next = -1
+                for u := 0; u < num; u++ {
+                    if status[cur][u] == 1 && u != prev {
+                        next = u
+                        break
+                    }
+                }
+                if next >= 0 {
+                    visited[next] = true
+                    size++
+                    prev, cur = cur, next
+                }
+            }
+
+            // the edge connecting the ends must not be used unless it completes the circle
+            if size < num && status[v][cur] == 0 {
+                status[v][cur], status[cur][v] = -1, -1
+                changed = true
+            }
+        }
+
+        // the remaining vertices with included edges are part of circles
+        for v := 0; v < num; v++ {
+            if visited[v] || included[v] != 2 {
+                continue
+            }
+            size := 0
+            for prev, cur := -1, v; !visited[cur]; size++ {
+                visited[cur] = true
+                for u := 0; u < num; u++ {
+                    if status[cur][u] == 1 && u != prev {
+                        prev, cur = cur, u
+                        break
+                    }
+                }
+            }
+            if size < num {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// returns the circle that is formed by the edges of the 1-tree, starting with vertex 0
+func (this *oneTreeSolver) treeToTour(tree [][2]int) []int {
+    adjacency := make([][]int, this.num)
+    for _, e := range tree {
+        adjacency[e[0]] = append(adjacency[e[0]], e[1])
+        adjacency[e[1]] = append(adjacency[e[1]], e[0])
+    }
+    tour := make([]int, 1, this.num)
+    for prev, cur := -1, 0; len(tour) < this.num; {
+        next := adjacency[cur][0]
+        if next == prev {
+            next = adjacency[cur][1]
+        }
+        tour = append(tour, next)
+        prev, cur = cur, next
+    }
+    return tour
+}
@@ -0,0 +1,121 @@
+package algorithm
+
+import (
+    "testing"
+    "math"
+    "math/rand"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the 1-tree branch and bound against the known optimal lengths
+func TestTravelingSalesmanOneTree(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_10e.txt": 27.26, "test/K_12.txt": 45.19, "test/K_12e.txt": 36.13} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+
+        tour, length, err := graph.TravelingSalesmanOneTree()
+        if err != nil {
+            t.Errorf("Expected no error for %s, got \"%s\".", file, err.Error())
+            continue
+        }
+        validateTour(t, graph, tour)
+        if float64(int(length * 100 + 0.5)) / 100 != optimum {
+            t.Errorf("Expected length %f for %s, got %f.", optimum, file, length)
+        }
+        if l := tourLength(graph.getWeightMatrix(), tourPositions(tour)); math.Abs(l - length) > 1e-6 {
+            t.Errorf("Expected tour of length %f for %s, got %f.", length, file, l)
+        }
+    }
+}
+
+// test the 1-tree branch and bound on random complete and incomplete graphs
+func TestOneTreeSolver(t *testing.T) {
+
+    // brute force the optimum with the first vertex fixed
+    var bruteForce func(weights [][]float64, tour []int, k int) float64
+    bruteForce = func(weights [][]float64, tour []int, k int) float64 {
+        if k == len(tour) {
+            return tourLength(weights, tour)
+        }
+        best := math.Inf(1)
+        for i := k; i < len(tour); i++ {
+            tour[k], tour[i] = tour[i], tour[k]
+            best = math.Min(best, bruteForce(weights, tour, k + 1))
+            tour[k], tour[i] = tour[i], tour[k]
+        }
+        return best
+    }
+
+    r := rand.New(rand.NewSource(42))
+    for round := 0; round < 40; round++ {
+        n := 4 + r.Intn(6)
+        weights := make([][]float64, n)
+        for i := range weights {
+            weights[i] = make([]float64, n)
+        }
+        for i := 0; i < n; i++ {
+            for j := i + 1; j < n; j++ {
+                weights[i][j] = float64(r.Intn(20))
+                if round % 2 == 1 && r.Intn(3) == 0 {
+                    weights[i][j] = math.Inf(1)
+                }
+                weights[j][i] = weights[i][j]
+            }
+        }
+        tour := make([]int, n)
+        for i := range tour {
+            tour[i] = i
+        }
+        expect := bruteForce(weights, tour, 1)
+
+        result, length := newOneTreeSolver(weights).solve()
+        if math.IsInf(expect, 1) {
+            if result != nil {
+                t.Errorf("Expected no circle, got %v.", result)
+            }
+            continue
+        }
+        if result == nil || len(result) != n {
+            t.Errorf("Expected circle of %d vertices, got %v.", n, result)
+            continue
+        }
+        if l := tourLength(weights, result); math.Abs(l - expect) > 1e-6 || math.Abs(length - expect) > 1e-6 {
+            t.Errorf("Expected length %f, got %f (%f).", expect, length, l)
+        }
+    }
+}
+
+// test that the 1-tree bound lies between the minimal spanning tree and the optimum
+func TestOneTreeLowerBound(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_12.txt": 45.19} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        graph := Graph{g}
+
+        mst, _, _ := graph.Prim(graph.GetVertices().Get(0))
+        if bound := graph.OneTreeLowerBound(); bound < mst || bound > optimum + 0.005 {
+            t.Errorf("Expected bound between %f and %f for %s, got %f.", mst, optimum, file, bound)
+        }
+    }
+}
+
+func BenchmarkTravelingSalesmanOneTree12(b *testing.B) {
+
+    g, err := parser.ParseEdgesFile("test/K_12.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+
+    for n := 0; n < b.N; n++ {
+        graph.TravelingSalesmanOneTree()
+    }
+
+}
@@ -0,0 +1,336 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "math/big"
+    "sort"
+)
+
+// simple wrapper
+func (this Graph) CountSpanningTrees() *big.Int {
+    return CountSpanningTrees(this)
+}
+
+// returns the number of spanning trees by Kirchhoff's matrix-tree theorem, which is 0 if the graph is not connected
+// Parallel edges are different edges, loops are ignored and so is the direction of the edges. The determinant is
+// calculated exactly with big integers, see LogCountSpanningTrees for large graphs.
+func CountSpanningTrees(graph Graph) *big.Int {
+    return spanningTreeCount(int(graph.GetVertices().Count()), graphEdgeEnds(graph))
+}
+
+// simple wrapper
+func (this Graph) LogCountSpanningTrees() float64 {
+    return LogCountSpanningTrees(this)
+}
+
+// returns the natural logarithm of the number of spanning trees, -Inf if the graph is not connected
+// It is calculated with floats, so it is fast but not exact. See CountSpanningTrees.
+func LogCountSpanningTrees(graph Graph) float64 {
+    num := int(graph.GetVertices().Count())
+    if num <= 1 {
+        return 0
+    }
+
+    // the laplacian without the first row and column
+    m := make([][]float64, num - 1)
+    for i := range m {
+        m[i] = make([]float64, num - 1)
+    }
+    for _, e := range graphEdgeEnds(graph) {
+        a, b := e[0] - 1, e[1] - 1
+        if a >= 0 {
+            m[a][a]++
+        }
+        if b >= 0 {
+            m[b][b]++
+        }
+        if a >= 0 && b >= 0 {
+            m[a][b]--
+            m[b][a]--
+        }
+    }
+
+    // gaussian elimination with partial pivoting, the determinant is the product of the pivots
+    result := 0.0
+    for k := range m {
+        p := k
+        for i := k + 1; i < len(m); i++ {
+            if math.Abs(m[i][k]) > math.Abs(m[p][k]) {
+                p = i
+            }
+        }
+        if math.Abs(m[p][k]) < 1e-9 {
+            return math.Inf(-1)
+        }
+        m[k], m[p] = m[p], m[k]
+        result += math.Log(math.Abs(m[k][k]))
+        for i := k + 1; i < len(m); i++ {
+            f := m[i][k] / m[k][k]
+            for j := k; j < len(m); j++ {
+                m[i][j] -= f * m[k][j]
+            }
+        }
+    }
+    return result
+}
+
+// simple wrapper
+func (this Graph) CountMinimalSpanningTrees() *big.Int {
+    return CountMinimalSpanningTrees(this)
+}
+
+// returns the number of minimal spanning trees, or minimal spanning forests if the graph is not connected
+// Every minimal spanning tree uses a spanning forest of each weight class in the graph where the lighter edges are
+// contracted, so the number is the product of the numbers of these forests.
+func CountMinimalSpanningTrees(graph Graph) *big.Int {
+    result := big.NewInt(1)
+    forEachWeightClass(graph, func(num int, edges [][2]int, _ []int) {
+        result.Mul(result, spanningForestCount(num, edges))
+    })
+    return result
+}
+
+// simple wrapper
+func (this Graph) AllMinimalSpanningTrees(limit int) (float64, [][]graphLib.EdgeInterface) {
+    return AllMinimalSpanningTrees(this, limit)
+}
+
+// returns the weight of the minimal spanning trees and up to limit of them, all of them if limit is not positive
+// If the graph is not connected, the minimal spanning forests are returned. The direction of the edges is ignored.
+func AllMinimalSpanningTrees(graph Graph, limit int) (float64, [][]graphLib.EdgeInterface) {
+    all := graph.GetEdges().All()
+
+    // the possible choices of edges of each weight class
+    weight, choices := 0.0, [][][]int{}
+    forEachWeightClass(graph, func(num int, edges [][2]int, indexes []int) {
+        forests := spanningForests(num, edges, limit)
+        for i, forest := range forests {
+            for j, e := range forest {
+                forest[j] = indexes[e]
+            }
+            if i == 0 {
+                for _, e := range forest {
+                    weight += all[e].GetWeight()
+                }
+            }
+        }
+        choices = append(choices, forests)
+    })
+
+    // combine one choice of each weight class
+    result := [][]graphLib.EdgeInterface{}
+    var combine func(class int, tree []graphLib.EdgeInterface)
+    combine = func(class int, tree []graphLib.EdgeInterface) {
+        if limit > 0 && len(result) >= limit {
+            return
+        }
+        if class == len(choices) {
+            result = append(result, append([]graphLib.EdgeInterface{}, tree...))
+            return
+        }
+        for _, forest := range choices[class] {
+            next := tree
+            for _, e := range forest {
+                next = append(next, all[e])
+            }
+            combine(class + 1, next)
+        }
+    }
+    combine(0, nil)
+    return weight, result
+}
+
+// returns the positions of the vertices of each edge of the graph
+func graphEdgeEnds(graph Graph) [][2]int {
+    all := graph.GetEdges().All()
+    ends := make([][2]int, len(all))
+    for i, edge := range all {
+        ends[i] = [2]int{edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos()}
+    }
+    return ends
+}
+
+// calls the function for each weight class of the edges from light to heavy
+// The vertices are the components of the lighter edges, numbered from 0 to num - 1. Only the edges between different
+// components are given, together with their indexes in the edges of the graph.
+func forEachWeightClass(graph Graph, f func(num int, edges [][2]int, indexes []int)) {
+    all, ends := graph.GetEdges().All(), graphEdgeEnds(graph)
+    order, weights := make([]int, len(all)), make([]float64, len(all))
+    for i, edge := range all {
+        order[i], weights[i] = i, edge.GetWeight()
+    }
+    sort.Stable(byWeightFrom{order, weights})
+
+    components := newUnionFind(int(graph.GetVertices().Count()))
+    for first := 0; first < len(order); {
+        last := first
+        for last < len(order) && weights[order[last]] == weights[order[first]] {
+            last++
+        }
+
+        // number the components that are connected by the class
+        number, edges, indexes := make(map[int]int), [][2]int{}, []int{}
+        for _, i := range order[first:last] {
+            a, b := components.find(ends[i][0]), components.find(ends[i][1])
+            if a == b {
+                continue
+            }
+            for _, c := range [2]int{a, b} {
+                if _, ok := number[c]; !ok {
+                    number[c] = len(number)
+                }
+            }
+            edges, indexes = append(edges, [2]int{number[a], number[b]}), append(indexes, i)
+        }
+        if len(edges) > 0 {
+            f(len(number), edges, indexes)
+        }
+
+        for _, i := range order[first:last] {
+            components.union(ends[i][0], ends[i][1])
+        }
+        first = last
+    }
+}
+
+// returns the number of spanning trees of the multigraph by Kirchhoff's matrix-tree theorem
+// The determinant of the laplacian without its first row and column is calculated with the fraction-free Bareiss algorithm.
+func spanningTreeCount(num int, edges [][2]int) *big.Int {
+    if num <= 1 {
+        return big.NewInt(1)
+    }
+    m := make([][]*big.Int, num - 1)
+    for i := range m {
+        m[i] = make([]*big.Int, num - 1)
+        for j := range m[i] {
+            m[i][j] = new(big.Int)
+        }
+    }
+    one := big.NewInt(1)
+    for _, e := range edges {
+        a, b := e[0] - 1, e[1] - 1
+        if a == b {
+            continue
+        }
+        if a >= 0 {
+            m[a][a].Add(m[a][a], one)
+        }
+        if b >= 0 {
+            m[b][b].Add(m[b][b], one)
+        }
+        if a >= 0 && b >= 0 {
+            m[a][b].Sub(m[a][b], one)
+            m[b][a].Sub(m[b][a], one)
+        }
+    }
+
+    negative, prev, t := false, big.NewInt(1), new(big.Int)
+    for k := range m {
+
+        // find a pivot
+        if m[k][k].Sign() == 0 {
+            p := k + 1
+            for p < len(m) && m[p][k].Sign() == 0 {
+                p++
+            }
+            if p == len(m) {
+                return new(big.Int)
+            }
+            m[k], m[p], negative = m[p], m[k], !negative
+        }
+
+        for i := k + 1; i < len(m); i++ {
+            for j := k + 1; j < len(m); j++ {
+                m[i][j].Mul(m[i][j], m[k][k])
+                m[i][j].Sub(m[i][j], t.Mul(m[i][k], m[k][j]))
+                m[i][j].Quo(m[i][j], prev)
+            }
+        }
+        prev = m[k][k]
+    }
+
+    result := new(big.Int).Set(m[len(m) - 1][len(m) - 1])
+    if negative {
+        result.Neg(result)
+    }
+    return result
+}
+
+// returns the number of spanning forests of the multigraph that have as many edges as possible
+// It is the product of the numbers of spanning trees of its connected components.
+func spanningForestCount(num int, edges [][2]int) *big.Int {
+    components := newUnionFind(num)
+    for _, e := range edges {
+        components.union(e[0], e[1])
+    }
+
+    // split the vertices and edges by component
+    number, sizes, parts := make([]int, num), make(map[int]int), make(map[int][][2]int)
+    for v := 0; v < num; v++ {
+        c := components.find(v)
+        number[v] = sizes[c]
+        sizes[c]++
+    }
+    for _, e := range edges {
+        c := components.find(e[0])
+        parts[c] = append(parts[c], [2]int{number[e[0]], number[e[1]]})
+    }
+
+    result := big.NewInt(1)
+    for c, part := range parts {
+        result.Mul(result, spanningTreeCount(sizes[c], part))
+    }
+    return result
+}
+
+// returns up to limit spanning forests of the multigraph that have as many edges as possible, all if limit is not positive
+// A forest is given by the indexes of its edges.
+func spanningForests(num int, edges [][2]int, limit int) [][]int {
+
+    // the number of edges of a forest
+    components, size := newUnionFind(num), 0
+    for _, e := range edges {
+        if components.union(e[0], e[1]) {
+            size++
+        }
+    }
+
+    // returns whether the edges from i on can still complete the forest
+    completable := func(i int, forest []int, components *unionFind) bool {
+        rest, n := components.clone(), len(forest)
+        for _, e := range edges[i:] {
+            if rest.union(e[0], e[1]) {
+                n++
+            }
+        }
+        return n == size
+    }
+
+    // decide for each edge whether it is part of the forest, the components are copied for every decision
+    // Every branch that is taken can be completed, so each forest is found after at most polynomially many steps.
+    result := [][]int{}
+    var choose func(i int, forest []int, components *unionFind)
+    choose = func(i int, forest []int, components *unionFind) {
+        if limit > 0 && len(result) >= limit {
+            return
+        }
+        if len(forest) == size {
+            result = append(result, append([]int{}, forest...))
+            return
+        }
+
+        // with the edge, if it does not close a circle, the forest can always be completed then
+        with := components.clone()
+        if with.union(edges[i][0], edges[i][1]) {
+            choose(i + 1, append(forest, i), with)
+        }
+
+        // without the edge, if the remaining edges are still enough
+        if completable(i + 1, forest, components) {
+            choose(i + 1, forest, components)
+        }
+    }
+    choose(0, nil, newUnionFind(num))
+    return result
+}
@@ -0,0 +1,183 @@
+package algorithm
+
+import (
+    "testing"
+    "math"
+    "math/big"
+    "math/rand"
+    "strings"
+    "fmt"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// returns the number of spanning trees and of minimal spanning trees by trying all sets of edges
+func countSpanningTreesBruteForce(num int, edges [][3]float64) (int64, int64) {
+    best := minimalSpanningTreeWeight(num, edges)
+    all, minimal := int64(0), int64(0)
+    for set := 0; set < 1 << uint(len(edges)); set++ {
+        if bitCount(set) != num - 1 {
+            continue
+        }
+        components, weight, tree := newUnionFind(num), 0.0, true
+        for i, e := range edges {
+            if set & (1 << uint(i)) != 0 {
+                tree = tree && components.union(int(e[0]), int(e[1]))
+                weight += e[2]
+            }
+        }
+        if tree {
+            all++
+            if math.Abs(weight - best) < 1e-9 {
+                minimal++
+            }
+        }
+    }
+    return all, minimal
+}
+
+// test the numbers of spanning trees against brute force
+func TestCountSpanningTrees(t *testing.T) {
+    r := rand.New(rand.NewSource(3))
+    for test := 0; test < 100; test++ {
+        num := 1 + r.Intn(6)
+        graph, edges := randomConnectedGraph(r, num, r.Intn(8))
+        all, minimal := countSpanningTreesBruteForce(num, edges)
+        if n := graph.CountSpanningTrees(); n.Cmp(big.NewInt(all)) != 0 {
+            t.Errorf("Expected %d spanning trees, got %v.", all, n)
+        }
+        if l := graph.LogCountSpanningTrees(); math.Abs(l - math.Log(float64(all))) > 1e-6 {
+            t.Errorf("Expected logarithm %f, got %f.", math.Log(float64(all)), l)
+        }
+        if n := graph.CountMinimalSpanningTrees(); n.Cmp(big.NewInt(minimal)) != 0 {
+            t.Errorf("Expected %d minimal spanning trees, got %v.", minimal, n)
+        }
+    }
+
+    // Cayley's formula: the complete graph has n^(n-2) spanning trees
+    g, err := parser.ParseEdgesFile("test/K_10.txt", true)
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+    if n := graph.CountSpanningTrees(); n.Cmp(big.NewInt(100000000)) != 0 {
+        t.Errorf("Expected 100000000 spanning trees, got %v.", n)
+    }
+    if l := graph.LogCountSpanningTrees(); math.Abs(l - 8 * math.Log(10)) > 1e-6 {
+        t.Errorf("Expected logarithm %f, got %f.", 8 * math.Log(10), l)
+    }
+
+    // a graph that is not connected has none
+    g, err = parser.ParseEdges(strings.NewReader("4\n0 1 1\n2 3 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    graph = Graph{g}
+    if n := graph.CountSpanningTrees(); n.Sign() != 0 {
+        t.Errorf("Expected no spanning trees, got %v.", n)
+    }
+    if l := graph.LogCountSpanningTrees(); !math.IsInf(l, -1) {
+        t.Errorf("Expected logarithm -Inf, got %f.", l)
+    }
+}
+
+// test that all minimal spanning trees are found and are different
+func TestAllMinimalSpanningTrees(t *testing.T) {
+    r := rand.New(rand.NewSource(4))
+    for test := 0; test < 100; test++ {
+        num := 2 + r.Intn(5)
+
+        // few different weights, so there are many ties
+        input := fmt.Sprintln(num)
+        for v := 1; v < num; v++ {
+            input += fmt.Sprintln(r.Intn(v), v, 1 + r.Intn(2))
+        }
+        for i := r.Intn(8); i > 0; i-- {
+            input += fmt.Sprintln(r.Intn(num), r.Intn(num), 1 + r.Intn(2))
+        }
+        g, err := parser.ParseEdges(strings.NewReader(input), true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+        edges := make([][3]float64, 0)
+        for _, e := range graph.GetEdges().All() {
+            edges = append(edges, [3]float64{float64(e.GetStartVertex().GetPos()), float64(e.GetEndVertex().GetPos()), e.GetWeight()})
+        }
+        _, minimal := countSpanningTreesBruteForce(num, edges)
+        best := minimalSpanningTreeWeight(num, edges)
+
+        weight, trees := graph.AllMinimalSpanningTrees(0)
+        if math.Abs(weight - best) > 1e-9 {
+            t.Errorf("Expected weight %f, got %f.", best, weight)
+        }
+        if int64(len(trees)) != minimal {
+            t.Errorf("Expected %d minimal spanning trees, got %d.", minimal, len(trees))
+        }
+        seen := make(map[string]bool)
+        for _, tree := range trees {
+            components, w, key := newUnionFind(num), 0.0, ""
+            for _, e := range tree {
+                if !components.union(e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()) {
+                    t.Errorf("Expected a tree, got a circle.")
+                }
+                w += e.GetWeight()
+                key += fmt.Sprintf("%p ", e)
+            }
+            if len(tree) != num - 1 || math.Abs(w - best) > 1e-9 {
+                t.Errorf("Expected %d edges of weight %f, got %d of weight %f.", num - 1, best, len(tree), w)
+            }
+            if seen[key] {
+                t.Errorf("Expected different trees, got one twice.")
+            }
+            seen[key] = true
+        }
+
+        // the limit
+        if _, trees := graph.AllMinimalSpanningTrees(2); len(trees) > 2 {
+            t.Errorf("Expected at most 2 trees, got %d.", len(trees))
+        }
+    }
+}
+
+// test enumerating from a weight class with many equal edges, where most choices can not be completed
+func TestAllMinimalSpanningTreesOneClass(t *testing.T) {
+
+    // the edge to vertex 0 comes first, then a path of 25 vertices and 25 more edges parallel to its last edge
+    // Leaving out the first edge leaves vertex 0 alone, but there are enough edges left to try all subsets of the path.
+    input := fmt.Sprintln(26) + fmt.Sprintln(0, 1, 1)
+    for v := 2; v <= 25; v++ {
+        input += fmt.Sprintln(v - 1, v, 1)
+    }
+    for i := 0; i < 25; i++ {
+        input += fmt.Sprintln(24, 25, 1)
+    }
+    g, err := parser.ParseEdges(strings.NewReader(input), true)
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+
+    if n := graph.CountMinimalSpanningTrees(); n.Cmp(big.NewInt(26)) != 0 {
+        t.Errorf("Expected 26 minimal spanning trees, got %v.", n)
+    }
+    weight, trees := graph.AllMinimalSpanningTrees(0)
+    if weight != 25 || len(trees) != 26 {
+        t.Errorf("Expected 26 trees of weight 25, got %d of weight %f.", len(trees), weight)
+    }
+    seen := make(map[string]bool)
+    for _, tree := range trees {
+        components, key := newUnionFind(26), ""
+        for _, e := range tree {
+            if !components.union(e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()) {
+                t.Errorf("Expected a tree, got a circle.")
+            }
+            key += fmt.Sprintf("%p ", e)
+        }
+        if len(tree) != 25 || seen[key] {
+            t.Errorf("Expected different trees of 25 edges, got one of %d edges or twice.", len(tree))
+        }
+        seen[key] = true
+    }
+}
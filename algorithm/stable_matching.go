@@ -0,0 +1,128 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "github.com/teelevision/fhac-mmi/parser"
+    "sort"
+)
+
+// simple wrapper
+func (this Graph) StableMatching() []graphLib.EdgeInterface {
+    return StableMatching(this)
+}
+
+// returns a stable matching using the Gale-Shapley algorithm
+// The vertices of group 0 propose to the vertices of group 1, which accept up to their capacity.
+// A lower rank means a higher preference. Edges without ranks use their weight as rank for both sides.
+func StableMatching(graph Graph) []graphLib.EdgeInterface {
+
+    num := int(graph.GetVertices().Count())
+    vertices := graph.GetVertices()
+
+    /*
+     * 1. Build the preference lists of the proposing vertices.
+     */
+    prefs := make([]stableMatchingProposals, num)
+    for _, e := range graph.GetEdges().All() {
+        s, t := e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()
+        sRank, tRank := getPreferenceRanks(e)
+
+        // make sure the proposing vertex is the one in group 0
+        if vertices.GetPos(s).(*parser.GroupVertex).GetGroup() == 1 {
+            s, t, sRank, tRank = t, s, tRank, sRank
+        }
+
+        prefs[s] = append(prefs[s], &stableMatchingProposal{
+            edge: e,
+            from: s,
+            to: t,
+            fromRank: sRank,
+            toRank: tRank,
+        })
+    }
+    for _, p := range prefs {
+        sort.Stable(p)
+    }
+
+    /*
+     * 2. Let free vertices propose until every one is matched or has no one left to propose to.
+     */
+    next := make([]int, num)
+    accepted := make([]stableMatchingProposals, num)
+    free := make([]int, 0, num)
+    for pos := num - 1; pos >= 0; pos-- {
+        if len(prefs[pos]) > 0 {
+            free = append(free, pos)
+        }
+    }
+    for len(free) > 0 {
+        s := free[len(free) - 1]
+        free = free[:len(free) - 1]
+
+        // no one left to propose to
+        if next[s] >= len(prefs[s]) {
+            continue
+        }
+
+        // propose to the next preferred vertex
+        p := prefs[s][next[s]]
+        next[s]++
+        accepted[p.to] = append(accepted[p.to], p)
+
+        // reject the least preferred proposal if the capacity is exceeded
+        if len(accepted[p.to]) > vertices.GetPos(p.to).(*parser.GroupVertex).GetCapacity() {
+            a, worst := accepted[p.to], 0
+            for i := range a {
+                // on equal ranks the later proposal is rejected
+                if a[i].toRank >= a[worst].toRank {
+                    worst = i
+                }
+            }
+            free = append(free, a[worst].from)
+            accepted[p.to] = append(a[:worst], a[worst + 1:]...)
+        }
+    }
+
+    /*
+     * 3. Build response.
+     */
+    matchedEdges := make([]graphLib.EdgeInterface, 0, num / 2)
+    for _, a := range accepted {
+        for _, p := range a {
+            matchedEdges = append(matchedEdges, p.edge)
+        }
+    }
+    return matchedEdges
+}
+
+// returns the rank the start vertex gives the end vertex and vice versa
+func getPreferenceRanks(e graphLib.EdgeInterface) (float64, float64) {
+    if p, ok := e.(*parser.PreferenceEdge); ok {
+        return p.GetStartRank(), p.GetEndRank()
+    }
+    return e.GetWeight(), e.GetWeight()
+}
+
+// a proposal of one vertex to another
+type stableMatchingProposal struct {
+    edge     graphLib.EdgeInterface
+    from     int
+    to       int
+    fromRank float64
+    toRank   float64
+}
+
+// a preference list that is sorted by the rank of the proposing vertex
+type stableMatchingProposals []*stableMatchingProposal
+
+func (this stableMatchingProposals) Len() int {
+    return len(this)
+}
+
+func (this stableMatchingProposals) Less(i, j int) bool {
+    return this[i].fromRank < this[j].fromRank
+}
+
+func (this stableMatchingProposals) Swap(i, j int) {
+    this[i], this[j] = this[j], this[i]
+}
@@ -0,0 +1,41 @@
+package algorithm
+
+import (
+    "testing"
+    "strings"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// test the Gale-Shapley algorithm
+func TestStableMatching(t *testing.T) {
+
+    // preferences (most preferred first):
+    // 0: 3 4 5    3: 1 0 2
+    // 1: 4 3 5    4: 0 1 2
+    // 2: 3 4 5    5: 0 1 2
+    edges := "0 3 1 2  0 4 2 1  0 5 3 1  1 3 2 1  1 4 1 2  1 5 3 2  2 3 1 3  2 4 2 3  2 5 3 3"
+
+    // test function
+    test := func(capacities string, expect [][2]int) {
+        g, err := parser.ParsePreference(strings.NewReader("6 3 " + capacities + " " + edges))
+        if err != nil {
+            panic(err)
+        }
+        matching := Graph{g}.StableMatching()
+        if len(matching) != len(expect) {
+            t.Errorf("Expected %d matching edges, got %d.", len(expect), len(matching))
+            return
+        }
+        for i, e := range matching {
+            if s, t2 := e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos(); s != expect[i][0] || t2 != expect[i][1] {
+                t.Errorf("Expected edge #%d to be %d -> %d, got %d -> %d.", i, expect[i][0], expect[i][1], s, t2)
+            }
+        }
+    }
+
+    // one-to-one: 2 is rejected by 3 and 4
+    test("1 1 1", [][2]int{{0, 3}, {1, 4}, {2, 5}})
+
+    // 3 can take two
+    test("2 1 1", [][2]int{{0, 3}, {2, 3}, {1, 4}})
+}
@@ -0,0 +1,302 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "errors"
+    "fmt"
+)
+
+// simple wrapper
+func (this Graph) SteinerTree(terminals []graphLib.VertexInterface, mehlhorn bool) (float64, []graphLib.EdgeInterface, error) {
+    return SteinerTree(this, terminals, mehlhorn)
+}
+
+// returns the weight and the edges of a tree that connects the terminals, it is at most twice as heavy as the lightest one
+// The minimal spanning tree of the terminals in the metric closure is expanded to shortest paths. The minimal
+// spanning tree of these paths without leaves that are no terminals is the result (Kou, Markowsky and Berman).
+// Mehlhorn's variant only uses the shortest paths between neighbouring Voronoi regions of the terminals, which needs
+// one instead of one Dijkstra per terminal. The graph has to be undirected without negative weights.
+func SteinerTree(graph Graph, terminals []graphLib.VertexInterface, mehlhorn bool) (float64, []graphLib.EdgeInterface, error) {
+    if graph.IsDirected() {
+        return 0, nil, errors.New("Steiner trees need an undirected graph.")
+    }
+    if err := checkNonNegativeWeights(graph); err != nil {
+        return 0, nil, err
+    }
+
+    // ignore terminals that are given twice
+    unique, seen := make([]graphLib.VertexInterface, 0, len(terminals)), make(map[graphLib.VertexInterface]bool, len(terminals))
+    for _, t := range terminals {
+        if !seen[t] {
+            unique, seen[t] = append(unique, t), true
+        }
+    }
+    terminals = unique
+    if len(terminals) < 2 {
+        return 0, []graphLib.EdgeInterface{}, nil
+    }
+
+    // all terminals have to be connected
+    distances, _ := dijkstra(graph, []int{terminals[0].GetPos()})
+    for _, t := range terminals {
+        if math.IsInf(distances[t.GetPos()], 1) {
+            return 0, nil, errors.New(fmt.Sprintf("Terminal %d is not connected to terminal %d.", t.GetId(), terminals[0].GetId()))
+        }
+    }
+
+    /*
+     * 1. Build the distance graph of the terminals and the shortest paths of its edges.
+     */
+    var distance *graphLib.Graph
+    var paths map[[2]int][]int
+    if mehlhorn {
+        distance, paths = mehlhornDistanceGraph(graph, terminals)
+    } else {
+        distance, paths = terminalDistanceGraph(graph, terminals)
+    }
+
+    /*
+     * 2. Get its minimal spanning tree.
+     */
+    _, mst, vMap := Prim(Graph{distance}, distance.GetVertices().GetPos(0))
+
+    /*
+     * 3. Replace its edges by the shortest paths in the graph.
+     */
+    tree := make([]int, len(vMap))
+    for v, t := range vMap {
+        tree[t.GetPos()] = v.GetPos()
+    }
+    sub := newEdgeSubset(graph)
+    for _, edge := range mst.GetEdges().All() {
+        a, b := tree[edge.GetStartVertex().GetPos()], tree[edge.GetEndVertex().GetPos()]
+        if a > b {
+            a, b = b, a
+        }
+        path := paths[[2]int{a, b}]
+        for i := 1; i < len(path); i++ {
+            sub.add(path[i - 1], path[i])
+        }
+    }
+
+    /*
+     * 4. Get the minimal spanning tree of these paths and remove leaves that are no terminals.
+     */
+    weight, edges := sub.minimalSpanningTree(terminals)
+    return weight, edges, nil
+}
+
+// returns the complete graph of the terminals with the lengths of the shortest paths as weights
+// The vertices have the positions of the terminals. The paths are given by vertex positions and indexed by the
+// positions of the terminals, the lower one first.
+func terminalDistanceGraph(graph Graph, terminals []graphLib.VertexInterface) (*graphLib.Graph, map[[2]int][]int) {
+    k := len(terminals)
+    distance := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(false, uint(k), uint(k * k / 2))
+    for _, t := range terminals {
+        distance.NewVertexWithId(t.GetId())
+    }
+    vertices, paths := distance.GetVertices(), make(map[[2]int][]int, k * k / 2)
+    for i, s := range terminals {
+        distances, prev := dijkstra(graph, []int{s.GetPos()})
+        for j := i + 1; j < k; j++ {
+            t := terminals[j].GetPos()
+            if math.IsInf(distances[t], 1) {
+                continue
+            }
+            distance.NewWeightedEdge(vertices.GetPos(i), vertices.GetPos(j), distances[t])
+            paths[[2]int{i, j}] = pathTo(prev, t)
+        }
+    }
+    return distance, paths
+}
+
+// returns the graph of the terminals whose Voronoi regions are neighbours, the weight of an edge is the length of
+// the shortest path between them that only passes these two regions, as described by Mehlhorn
+// The vertices, weights and paths are the same as the ones of terminalDistanceGraph.
+func mehlhornDistanceGraph(graph Graph, terminals []graphLib.VertexInterface) (*graphLib.Graph, map[[2]int][]int) {
+    k := len(terminals)
+
+    // the region of each vertex is the one of its nearest terminal
+    sources, index := make([]int, k), make(map[int]int, k)
+    for i, t := range terminals {
+        sources[i], index[t.GetPos()] = t.GetPos(), i
+    }
+    distances, prev := dijkstra(graph, sources)
+    region := make([]int, len(prev))
+    for v := range region {
+        region[v] = -1
+    }
+    var regionOf func(v int) int
+    regionOf = func(v int) int {
+        if region[v] < 0 {
+            if prev[v] < 0 {
+                region[v] = index[v]
+            } else {
+                region[v] = regionOf(prev[v])
+            }
+        }
+        return region[v]
+    }
+
+    // the lightest edge between each pair of neighbouring regions, in the order they are found
+    type bridge struct {
+        pair   [2]int
+        weight float64
+        u, v   int
+    }
+    bridges, found := []bridge{}, make(map[[2]int]int)
+    for _, edge := range graph.GetEdges().All() {
+        u, v := edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos()
+        if math.IsInf(distances[u], 1) || math.IsInf(distances[v], 1) {
+            continue
+        }
+        a, b := regionOf(u), regionOf(v)
+        if a == b {
+            continue
+        }
+        if a > b {
+            a, b, u, v = b, a, v, u
+        }
+        weight := distances[u] + edge.GetWeight() + distances[v]
+        if i, ok := found[[2]int{a, b}]; !ok {
+            found[[2]int{a, b}] = len(bridges)
+            bridges = append(bridges, bridge{[2]int{a, b}, weight, u, v})
+        } else if weight < bridges[i].weight {
+            bridges[i] = bridge{[2]int{a, b}, weight, u, v}
+        }
+    }
+
+    distance := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(false, uint(k), uint(len(bridges)))
+    for _, t := range terminals {
+        distance.NewVertexWithId(t.GetId())
+    }
+    vertices, paths := distance.GetVertices(), make(map[[2]int][]int, len(bridges))
+    for _, b := range bridges {
+        distance.NewWeightedEdge(vertices.GetPos(b.pair[0]), vertices.GetPos(b.pair[1]), b.weight)
+
+        // from the terminal of a to u, then back from v to the terminal of b
+        path := pathTo(prev, b.u)
+        for v := b.v; v >= 0; v = prev[v] {
+            path = append(path, v)
+        }
+        paths[b.pair] = path
+    }
+    return distance, paths
+}
+
+// returns the path from the source to t as vertex positions
+func pathTo(prev []int, t int) []int {
+    path := []int{}
+    for v := t; v >= 0; v = prev[v] {
+        path = append(path, v)
+    }
+    reverseInts(path)
+    return path
+}
+
+// a subgraph given by some edges of a graph, each pair of vertices is connected by its lightest edge
+// The pairs are kept in the order they were added, so that the results do not depend on the order of the map.
+type edgeSubset struct {
+    graph Graph
+    edges map[[2]int]graphLib.EdgeInterface
+    pairs [][2]int
+}
+
+// creates an empty subgraph
+func newEdgeSubset(graph Graph) *edgeSubset {
+    return &edgeSubset{graph, make(map[[2]int]graphLib.EdgeInterface), nil}
+}
+
+// adds the lightest edge between the vertices at the given positions
+func (this *edgeSubset) add(a, b int) {
+    if a > b {
+        a, b = b, a
+    }
+    if _, ok := this.edges[[2]int{a, b}]; ok {
+        return
+    }
+    va, vb := this.graph.GetVertices().GetPos(a), this.graph.GetVertices().GetPos(b)
+    var lightest graphLib.EdgeInterface
+    for _, edge := range this.graph.getEdgesOfVertex(va).All() {
+        if edge.GetOtherVertex(va) == vb && (lightest == nil || edge.GetWeight() < lightest.GetWeight()) {
+            lightest = edge
+        }
+    }
+    this.edges[[2]int{a, b}] = lightest
+    this.pairs = append(this.pairs, [2]int{a, b})
+}
+
+// returns the weight and the edges of the minimal spanning tree of the subgraph without leaves that are no terminals
+func (this *edgeSubset) minimalSpanningTree(terminals []graphLib.VertexInterface) (float64, []graphLib.EdgeInterface) {
+
+    // build the subgraph with its own vertices
+    sub := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(false, uint(len(this.edges) + 1), uint(len(this.edges)))
+    vertices := make(map[int]graphLib.VertexInterface, len(this.edges) + 1)
+    vertex := func(pos int) graphLib.VertexInterface {
+        if v, ok := vertices[pos]; ok {
+            return v
+        }
+        vertices[pos] = sub.NewVertexWithId(uint(pos))
+        return vertices[pos]
+    }
+    for _, pair := range this.pairs {
+        sub.NewWeightedEdge(vertex(pair[0]), vertex(pair[1]), this.edges[pair].GetWeight())
+    }
+
+    // the spanning tree as neighbours of each vertex, given by original positions
+    _, mst, vMap := Prim(Graph{sub}, vertices[terminals[0].GetPos()])
+    original := make(map[graphLib.VertexInterface]int, len(vMap))
+    for v, t := range vMap {
+        original[t] = int(v.GetId())
+    }
+    neighbours := make(map[int]map[int]bool, len(vMap))
+    for _, edge := range mst.GetEdges().All() {
+        a, b := original[edge.GetStartVertex()], original[edge.GetEndVertex()]
+        for _, pair := range [2][2]int{{a, b}, {b, a}} {
+            if neighbours[pair[0]] == nil {
+                neighbours[pair[0]] = make(map[int]bool)
+            }
+            neighbours[pair[0]][pair[1]] = true
+        }
+    }
+
+    // remove leaves that are no terminals until there are none left
+    terminal := make(map[int]bool, len(terminals))
+    for _, t := range terminals {
+        terminal[t.GetPos()] = true
+    }
+    leaves := []int{}
+    for _, pair := range this.pairs {
+        for _, v := range pair {
+            if len(neighbours[v]) == 1 && !terminal[v] {
+                leaves = append(leaves, v)
+            }
+        }
+    }
+    for len(leaves) > 0 {
+        v := leaves[len(leaves) - 1]
+        leaves = leaves[:len(leaves) - 1]
+        if neighbours[v] == nil {
+            // found twice
+            continue
+        }
+        for w := range neighbours[v] {
+            delete(neighbours[w], v)
+            if len(neighbours[w]) == 1 && !terminal[w] {
+                leaves = append(leaves, w)
+            }
+        }
+        delete(neighbours, v)
+    }
+
+    // the remaining edges
+    weight, result := 0.0, make([]graphLib.EdgeInterface, 0, len(neighbours))
+    for _, pair := range this.pairs {
+        if neighbours[pair[0]][pair[1]] {
+            weight += this.edges[pair].GetWeight()
+            result = append(result, this.edges[pair])
+        }
+    }
+    return weight, result
+}
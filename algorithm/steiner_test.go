@@ -0,0 +1,134 @@
+package algorithm
+
+import (
+    "testing"
+    "fmt"
+    "math"
+    "math/rand"
+    "strings"
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// returns the weight of the lightest steiner tree: the lightest minimal spanning tree of all vertex sets with the terminals
+func bruteForceSteinerTree(weights [][]float64, terminals []int) float64 {
+    num, best := len(weights), math.Inf(1)
+    for set := 0; set < 1 << uint(num); set++ {
+        vertices := []int{}
+        for v := 0; v < num; v++ {
+            if set & (1 << uint(v)) != 0 {
+                vertices = append(vertices, v)
+            }
+        }
+        ok := true
+        for _, t := range terminals {
+            ok = ok && set & (1 << uint(t)) != 0
+        }
+        if !ok {
+            continue
+        }
+
+        // prim on the weight matrix
+        distance, inTree, weight := make([]float64, num), make([]bool, num), 0.0
+        for _, v := range vertices {
+            distance[v] = weights[vertices[0]][v]
+        }
+        inTree[vertices[0]] = true
+        for n := 1; n < len(vertices); n++ {
+            next := -1
+            for _, v := range vertices {
+                if !inTree[v] && (next < 0 || distance[v] < distance[next]) {
+                    next = v
+                }
+            }
+            weight += distance[next]
+            inTree[next] = true
+            for _, v := range vertices {
+                distance[v] = math.Min(distance[v], weights[next][v])
+            }
+        }
+        best = math.Min(best, weight)
+    }
+    return best
+}
+
+// checks that the edges form a tree that connects the terminals and whose leaves are terminals
+func validateSteinerTree(t *testing.T, terminals []graphLib.VertexInterface, edges []graphLib.EdgeInterface) {
+    degree, components := make(map[int]int), newUnionFind(100)
+    for _, e := range edges {
+        a, b := e.GetStartVertex().GetPos(), e.GetEndVertex().GetPos()
+        degree[a]++
+        degree[b]++
+        if !components.union(a, b) {
+            t.Errorf("Expected a tree, the edge %d - %d closes a circle.", a, b)
+        }
+    }
+    isTerminal := make(map[int]bool)
+    for _, v := range terminals {
+        isTerminal[v.GetPos()] = true
+        if components.find(v.GetPos()) != components.find(terminals[0].GetPos()) {
+            t.Errorf("Expected terminal %d to be connected.", v.GetId())
+        }
+    }
+    for v, d := range degree {
+        if d == 1 && !isTerminal[v] {
+            t.Errorf("Expected vertex %d to be no leaf.", v)
+        }
+    }
+}
+
+// test both variants against the lightest steiner tree on random graphs
+func TestSteinerTree(t *testing.T) {
+    r := rand.New(rand.NewSource(1))
+    for n := 0; n < 100; n++ {
+        num := 4 + r.Intn(6)
+        input := fmt.Sprintln(num)
+        for v := 1; v < num; v++ {
+            input += fmt.Sprintln(r.Intn(v), v, 1 + r.Intn(9))
+        }
+        for i := 0; i < num; i++ {
+            input += fmt.Sprintln(r.Intn(num), r.Intn(num), 1 + r.Intn(9))
+        }
+        g, err := parser.ParseEdges(strings.NewReader(input), true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+
+        positions := r.Perm(num)[:2 + r.Intn(num - 2)]
+        terminals := graph.tourVertices(positions)
+        optimum := bruteForceSteinerTree(graph.getWeightMatrix(), positions)
+
+        for _, mehlhorn := range []bool{false, true} {
+            weight, edges, err := graph.SteinerTree(terminals, mehlhorn)
+            if err != nil {
+                t.Fatal(err)
+            }
+            validateSteinerTree(t, terminals, edges)
+            sum := 0.0
+            for _, e := range edges {
+                sum += e.GetWeight()
+            }
+            if sum != weight || weight < optimum || weight > 2 * optimum {
+                t.Errorf("Expected weight between %f and %f (mehlhorn %v), got %f with edges of %f for\n%s", optimum, 2 * optimum, mehlhorn, weight, sum, input)
+            }
+        }
+    }
+}
+
+// test failing for terminals that are not connected
+func TestSteinerTreeError(t *testing.T) {
+    g, err := parser.ParseEdges(strings.NewReader("4\n0 1 1\n2 3 1\n"), true)
+    if err != nil {
+        panic(err)
+    }
+    graph := Graph{g}
+    if _, _, err := graph.SteinerTree(graph.tourVertices([]int{0, 1}), false); err == nil {
+        t.Error("Expected error for a directed graph, got nil.")
+    }
+    g.SetDirected(false)
+    if _, _, err := graph.SteinerTree(graph.tourVertices([]int{0, 3}), true); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}
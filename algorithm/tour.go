@@ -0,0 +1,36 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+)
+
+// returns the length of the closed tour given by vertex positions
+func tourLength(weights [][]float64, tour []int) float64 {
+    length := 0.0
+    for i := 1; i < len(tour); i++ {
+        length += weights[tour[i - 1]][tour[i]]
+    }
+    if len(tour) > 1 {
+        length += weights[tour[len(tour) - 1]][tour[0]]
+    }
+    return length
+}
+
+// returns the vertices of the graph at the positions of the tour
+func (this Graph) tourVertices(tour []int) []graphLib.VertexInterface {
+    vertices := this.GetVertices()
+    result := make([]graphLib.VertexInterface, len(tour))
+    for i, pos := range tour {
+        result[i] = vertices.GetPos(pos)
+    }
+    return result
+}
+
+// returns the positions of the vertices of the tour
+func tourPositions(tour []graphLib.VertexInterface) []int {
+    result := make([]int, len(tour))
+    for i, v := range tour {
+        result[i] = v.GetPos()
+    }
+    return result
+}
@@ -3,18 +3,25 @@ package algorithm
 import (
     graphLib "github.com/teelevision/fhac-mmi/graph"
     "math"
+    "errors"
+    "fmt"
+    "runtime"
+    "sync"
+    "sync/atomic"
 )
 
 // the maximum supported number of vertices in the traveling salesman brute force algorithm
 const tsbfMax = 15
 
 // simple wrapper
-func (this Graph) TravelingSalesmanBruteForce(branchAndBound bool) float64 {
-    return TravelingSalesmanBruteForce(this, branchAndBound)
+func (this Graph) TravelingSalesmanBruteForce(branchAndBound bool, workers int) ([]graphLib.VertexInterface, float64, error) {
+    return TravelingSalesmanBruteForce(this, branchAndBound, workers)
 }
 
-// returns the length of the shortest hamilton circle by brute force
-func TravelingSalesmanBruteForce(graph Graph, branchAndBound bool) float64 {
+// returns the shortest hamilton circle and its length by brute force
+// The search is split across the given number of goroutines, or one per CPU if workers is not positive.
+// Directed graphs are solved as asymmetric instances.
+func TravelingSalesmanBruteForce(graph Graph, branchAndBound bool, workers int) ([]graphLib.VertexInterface, float64, error) {
 
     // ----------------------------------------------
     // check pre-requirements
@@ -25,7 +32,7 @@ func TravelingSalesmanBruteForce(graph Graph, branchAndBound bool) float64 {
 
     // this algorithm only works with 5 to 15 vertices
     if num <= 4 || num > tsbfMax {
-        return 0
+        return nil, 0, errors.New(fmt.Sprintf("Brute force only supports 5 to %d vertices, got %d.", tsbfMax, num))
     }
 
     // ----------------------------------------------
@@ -36,166 +43,213 @@ func TravelingSalesmanBruteForce(graph Graph, branchAndBound bool) float64 {
     lastVertex := num - 1
 
     // use own vertex structure that has an index which refers to its position in the vertices array
-    // it also contains an array to each other position containing the distance between the vertices
+    // it also contains arrays to each other position containing the distance to and from the other vertex
     type vertex struct {
         graphLib.VertexInterface
         index     int
         distances [tsbfMax]float64
+        back      [tsbfMax]float64
     }
 
     // create vertices and calculate distance once here instead of doing it multiple times later
-    var vertices [tsbfMax]*vertex
+    // In undirected graphs the distances are the same in both directions.
+    var original [tsbfMax]*vertex
+    matrix := graph.getDistanceMatrix()
     for i, v := range graph.GetVertices().All() {
 
         // create vertex
-        vertices[i] = &vertex{
+        original[i] = &vertex{
             VertexInterface: v,
             index: i,
         }
-
-        // add distances for all previously created vertices
-        for j, v2 := range vertices[:i] {
-            w := graph.getWeightBetween(v, v2.VertexInterface)
-            // make the distances two-way
-            vertices[i].distances[j] = w
-            v2.distances[i] = w
+        for j := 0; j < num; j++ {
+            original[i].distances[j], original[i].back[j] = matrix[i][j], matrix[j][i]
         }
 
     }
 
     // the shortest circle's length will be stored in here
-    length := math.MaxFloat64
+    // It is shared by all workers and therefore only accessed atomically as the bits of the float.
+    length := math.Float64bits(math.MaxFloat64)
+
+    // the shortest circle as indexes of the vertices, the mutex guards it together with writing the length
+    var tour []int
+    var tourMutex sync.Mutex
+    shortest := func() float64 {
+        return math.Float64frombits(atomic.LoadUint64(&length))
+    }
 
     // ----------------------------------------------
-    // build helpers
+    // Strategy
     // ----------------------------------------------
-    // There will be 2 different helpers:
-    // * The intermediate helper will be called recursively and ...
-    //   ... creates all possible permutations of the remaining vertices.
-    // * The end helper will be called at the end of the recursion and ...
-    //   ... updates the length variable if a new shortest circle was found.
+    // 1. Pick 0 as start vertex.
+    // 2. Pick every _set_ of two vertices and put one left and one right of the start vertex.
+    //    Every set is a job for one of the workers.
+    // 3. Use every permutation of the remaining vertices to close the circle and calculate the length.
+    //    Use recursion for the permutation.
     // ----------------------------------------------
 
-    // there will be 2 different helper functions. both will be stored in this array
-    var helpers [tsbfMax - 2]func(int, *vertex, *vertex, float64)
+    // ----------------------------------------------
+    // 1. Pick 0 as start vertex.
+    // ----------------------------------------------
+    startDist, startBack := original[0].distances, original[0].back
 
-    // end helper
-    helpers[num - 3] = func(n int, front, end *vertex, currentLength float64) {
-        rest0index := vertices[n].index
-        l := currentLength + front.distances[rest0index] + end.distances[rest0index]
-        if l < length {
-            length = l
+    // ----------------------------------------------
+    // 2. Pick every _set_ of two vertices and put one left and one right of the start vertex
+    // ----------------------------------------------
+    jobs := make(chan [2]int, num * num)
+    for i := 1; i <= lastVertex; i++ {
+        // select second element of the set that is greater than the first one
+        // In directed graphs the circle can not be reversed, so the second element may also be smaller.
+        for j := 1; j <= lastVertex; j++ {
+            if j > i || j != i && graph.IsDirected() {
+                jobs <- [2]int{i, j}
+            }
         }
     }
+    close(jobs)
+
+    // a worker takes jobs until there are none left
+    worker := func() {
+
+        // each worker has its own permutation state
+        // The path contains the indexes of the vertices in the order of the current circle.
+        vertices := original
+        var path [tsbfMax]int
+
+        // ----------------------------------------------
+        // build helpers
+        // ----------------------------------------------
+        // There will be 2 different helpers:
+        // * The intermediate helper will be called recursively and ...
+        //   ... creates all possible permutations of the remaining vertices.
+        // * The end helper will be called at the end of the recursion and ...
+        //   ... updates the length and tour variables if a new shortest circle was found.
+        // ----------------------------------------------
+
+        // there will be 2 different helper functions. both will be stored in this array
+        var helpers [tsbfMax - 2]func(int, *vertex, *vertex, float64)
+
+        // end helper
+        helpers[num - 3] = func(n int, front, end *vertex, currentLength float64) {
+            rest0index := vertices[n].index
+            l := currentLength + front.distances[rest0index] + end.back[rest0index]
+            if l < shortest() {
+                tourMutex.Lock()
+                // another worker might have found a shorter circle in the meantime
+                if l < shortest() {
+                    atomic.StoreUint64(&length, math.Float64bits(l))
+                    path[n - 1], path[n], path[n + 1] = front.index, rest0index, end.index
+                    tour = append(tour[:0], path[:num]...)
+                }
+                tourMutex.Unlock()
+            }
+        }
 
-    // intermediate helper
-    for i := num - 4; i >= 1; i-- {
-        if branchAndBound {
-            // when using branch and bound, check the length on each recursion level
-            // abort if already longer than the shortest known circle
-            helpers[i] = func(n int, front, end *vertex, currentLength float64) {
-
-                n1, rest0, rest0Temp, frontDist, weightTmp := n + 1, vertices[n], (*vertex)(nil), &front.distances, 0.0
-                next := helpers[n]
+        // intermediate helper
+        for i := num - 4; i >= 1; i-- {
+            if branchAndBound {
+                // when using branch and bound, check the length on each recursion level
+                // abort if already longer than the shortest known circle of all workers
+                helpers[i] = func(n int, front, end *vertex, currentLength float64) {
+
+                    n1, rest0, rest0Temp, frontDist, weightTmp := n + 1, vertices[n], (*vertex)(nil), &front.distances, 0.0
+                    next := helpers[n]
+                    path[n - 1] = front.index
+
+                    // when not changing the order
+                    weightTmp = currentLength + frontDist[rest0.index]
+                    if weightTmp < shortest() {
+                        next(n1, rest0, end, weightTmp)
+                    }
 
-                // when not changing the order
-                weightTmp = currentLength + frontDist[rest0.index]
-                if weightTmp < length {
-                    next(n1, rest0, end, weightTmp)
-                }
+                    // combinations of changing the order
+                    for i := n1; i < lastVertex; i++ {
 
-                // combinations of changing the order
-                for i := n1; i < lastVertex; i++ {
+                        // change order
+                        rest0Temp, vertices[i] = vertices[i], rest0
 
-                    // change order
-                    rest0Temp, vertices[i] = vertices[i], rest0
+                        // recursion
+                        weightTmp = currentLength + frontDist[rest0Temp.index]
+                        if weightTmp < shortest() {
+                            next(n1, rest0Temp, end, weightTmp)
+                        }
 
-                    // recursion
-                    weightTmp = currentLength + frontDist[rest0Temp.index]
-                    if weightTmp < length {
-                        next(n1, rest0Temp, end, weightTmp)
+                        // change back
+                        vertices[i] = rest0Temp
                     }
-
-                    // change back
-                    vertices[i] = rest0Temp
                 }
-            }
-        } else {
-            helpers[i] = func(n int, front, end *vertex, currentLength float64) {
+            } else {
+                helpers[i] = func(n int, front, end *vertex, currentLength float64) {
 
-                n1, rest0, rest0Temp, frontDist := n + 1, vertices[n], (*vertex)(nil), &front.distances
-                next := helpers[n]
+                    n1, rest0, rest0Temp, frontDist := n + 1, vertices[n], (*vertex)(nil), &front.distances
+                    next := helpers[n]
+                    path[n - 1] = front.index
 
-                // when not changing the order
-                next(n1, rest0, end, currentLength + frontDist[rest0.index])
+                    // when not changing the order
+                    next(n1, rest0, end, currentLength + frontDist[rest0.index])
 
-                // combinations of changing the order
-                for i := n1; i < lastVertex; i++ {
+                    // combinations of changing the order
+                    for i := n1; i < lastVertex; i++ {
 
-                    // change order
-                    rest0Temp, vertices[i] = vertices[i], rest0
+                        // change order
+                        rest0Temp, vertices[i] = vertices[i], rest0
 
-                    // recursion
-                    next(n1, rest0Temp, end, currentLength + frontDist[rest0Temp.index])
+                        // recursion
+                        next(n1, rest0Temp, end, currentLength + frontDist[rest0Temp.index])
 
-                    // change back
-                    vertices[i] = rest0Temp
+                        // change back
+                        vertices[i] = rest0Temp
+                    }
                 }
             }
         }
-    }
 
-    // ----------------------------------------------
-    // Strategy
-    // ----------------------------------------------
-    // 1. Pick 0 as start vertex.
-    // 2. Pick every _set_ of two vertices and put one left and one right of the start vertex
-    // 3. Use every permutation of the remaining vertices to close the circle and calculate the length.
-    //    Use recursion for the permutation.
-    // ----------------------------------------------
+        for job := range jobs {
+            i, j := job[0], job[1]
 
-    // ----------------------------------------------
-    // 1. Pick 0 as start vertex.
-    // ----------------------------------------------
-    startDist := vertices[0].distances
-
-    // ----------------------------------------------
-    // 2. Pick every _set_ of two vertices and put one left and one right of the start vertex
-    // ----------------------------------------------
-
-    // when swapping vertices, use this variables for the front and end to read from (faster than array access)
-    rest0, end := vertices[1], vertices[lastVertex]
-
-    // when swapping vertices, use this variables for the front and end to write to (faster than array access)
-    var rest0Temp, endTemp *vertex
-
-    // select first element of the set
-    for i := 1; i < lastVertex; i++ {
-
-        // swap. we do not actually need to swap, ...
-        // ... because on the next recursion levels only the indexes 2 upwards are accessed
-        rest0Temp, vertices[i] = vertices[i], rest0
-
-        // select second element of the set that is greater than the first one
-        for j := i + 1; j <= lastVertex; j++ {
-
-            // swap. we do not actually need to swap, ...
-            // ... because on the next recursion levels the last vertex is never accessed
-            endTemp, vertices[j] = vertices[j], end
+            // swap the first element to the front and the second one to the end, ...
+            // ... because on the next recursion levels only the indexes 2 up to the last but one are accessed
+            // if the second element was at the front, it is at the place of the first one now
+            k := j
+            if j == 1 {
+                k = i
+            }
+            vertices[1], vertices[i] = vertices[i], vertices[1]
+            vertices[lastVertex], vertices[k] = vertices[k], vertices[lastVertex]
 
             // ----------------------------------------------
             // 3. Use every permutation of the remaining vertices to close the circle and calculate the length.
             // ----------------------------------------------
-            helpers[1](2, rest0Temp, endTemp, startDist[i] + startDist[j])
+            helpers[1](2, vertices[1], vertices[lastVertex], startDist[i] + startBack[j])
 
-            // swap back. since we did not really swap above, this is as simple as an assignment
-            vertices[j] = endTemp
+            // swap back
+            vertices[lastVertex], vertices[k] = vertices[k], vertices[lastVertex]
+            vertices[1], vertices[i] = vertices[i], vertices[1]
         }
+    }
 
-        // swap back. since we did not really swap above, this is as simple as an assignment
-        vertices[i] = rest0Temp
+    // start the workers
+    if workers <= 0 {
+        workers = runtime.NumCPU()
     }
+    var wg sync.WaitGroup
+    wg.Add(workers)
+    for w := 0; w < workers; w++ {
+        go func() {
+            defer wg.Done()
+            worker()
+        }()
+    }
+    wg.Wait()
 
-    // returns the length of the shortest hamilton circle
-    return length
+    // returns the shortest hamilton circle and its length
+    if tour == nil {
+        return nil, 0, errors.New("No hamilton circle was found.")
+    }
+    result := make([]graphLib.VertexInterface, num)
+    for i, index := range tour {
+        result[i] = original[index].VertexInterface
+    }
+    return result, shortest(), nil
 }
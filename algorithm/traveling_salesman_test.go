@@ -2,19 +2,53 @@ package algorithm
 
 import (
     "testing"
+    "math"
     "github.com/teelevision/fhac-mmi/parser"
 )
 
+// test the brute force with different numbers of workers
+func TestTravelingSalesmanBruteForce(t *testing.T) {
+    for file, optimum := range map[string]float64{"test/K_10.txt": 38.41, "test/K_10e.txt": 27.26} {
+        g, err := parser.ParseEdgesFile(file, true)
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+
+        for _, workers := range []int{0, 1, 3} {
+            for _, branchAndBound := range []bool{false, true} {
+                tour, length, err := graph.TravelingSalesmanBruteForce(branchAndBound, workers)
+                if err != nil {
+                    t.Errorf("Expected no error for %s, got \"%s\".", file, err.Error())
+                    continue
+                }
+                validateTour(t, graph, tour)
+                if float64(int(length * 100 + 0.5)) / 100 != optimum {
+                    t.Errorf("Expected length %f for %s with %d workers, got %f.", optimum, file, workers, length)
+                }
+                if l := tourLength(graph.getWeightMatrix(), tourPositions(tour)); math.Abs(l - length) > 1e-6 {
+                    t.Errorf("Expected tour of length %f for %s, got %f.", length, file, l)
+                }
+            }
+        }
+    }
+}
+
 func TravelingSalesmanBruteForceBenchmark(b *testing.B, file string, result float64, branchAndBound bool) {
 
     g, err := parser.ParseEdgesFile(file, true)
     if err != nil {
         panic(err)
     }
+    g.SetDirected(false)
     graph := Graph{g}
 
     for n := 0; n < b.N; n++ {
-        length := graph.TravelingSalesmanBruteForce(branchAndBound)
+        _, length, err := graph.TravelingSalesmanBruteForce(branchAndBound, 0)
+        if err != nil {
+            panic(err)
+        }
         if float64(int(length * 100 + 0.5)) / 100 != result {
             panic("TravelingSalesmanBruteForce() result is wrong")
         }
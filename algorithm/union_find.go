@@ -0,0 +1,56 @@
+package algorithm
+
+// disjoint sets of vertex positions with path compression and union by rank
+type unionFind struct {
+    parent []int
+    rank   []int
+}
+
+// creates the sets with each vertex being alone
+func newUnionFind(num int) *unionFind {
+    u := &unionFind{
+        parent: make([]int, num),
+        rank: make([]int, num),
+    }
+    for v := range u.parent {
+        u.parent[v] = v
+    }
+    return u
+}
+
+// returns an independent copy of the sets
+func (this *unionFind) clone() *unionFind {
+    return &unionFind{append([]int{}, this.parent...), append([]int{}, this.rank...)}
+}
+
+// returns the representative of the set of v
+func (this *unionFind) find(v int) int {
+    root := v
+    for this.parent[root] != root {
+        root = this.parent[root]
+    }
+
+    // let the whole path point to the root
+    for this.parent[v] != root {
+        v, this.parent[v] = this.parent[v], root
+    }
+    return root
+}
+
+// merges the sets of a and b, returns false if they are the same already
+func (this *unionFind) union(a, b int) bool {
+    a, b = this.find(a), this.find(b)
+    if a == b {
+        return false
+    }
+
+    // the lower tree is attached to the higher one
+    if this.rank[a] < this.rank[b] {
+        a, b = b, a
+    }
+    this.parent[b] = a
+    if this.rank[a] == this.rank[b] {
+        this.rank[a]++
+    }
+    return true
+}
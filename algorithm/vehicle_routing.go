@@ -0,0 +1,250 @@
+package algorithm
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "math"
+    "sort"
+    "errors"
+    "fmt"
+)
+
+// a vertex with a demand, as parsed by parser.ParseDemand
+type demandVertex interface {
+    GetDemand() float64
+}
+
+// a route of a vehicle that starts and ends at the depot
+// The vertices do not contain the depot.
+type VehicleRoute struct {
+    Vertices []graphLib.VertexInterface
+    Load     float64
+    Length   float64
+}
+
+// simple wrapper
+func (this Graph) VehicleRoutingSavings(depot graphLib.VertexInterface, capacity float64) ([]VehicleRoute, float64, error) {
+    return VehicleRoutingSavings(this, depot, capacity)
+}
+
+// solves the capacitated vehicle routing problem with the savings algorithm of Clarke and Wright
+// The routes are improved by 2-opt within each route and by exchanging the ends of two routes.
+// Returns the routes and their total length. Vertices without a demand have a demand of 1.
+// The routes are reversed by 2-opt, so directed graphs need the same distances in both directions.
+func VehicleRoutingSavings(graph Graph, depot graphLib.VertexInterface, capacity float64) ([]VehicleRoute, float64, error) {
+    weights, err := graph.getSymmetricDistanceMatrix("The savings algorithm")
+    if err != nil {
+        return nil, 0, err
+    }
+    d := depot.GetPos()
+
+    // get the demands and check that every vertex can be supplied
+    demands := make([]float64, len(weights))
+    for _, v := range graph.GetVertices().All() {
+        demands[v.GetPos()] = 1
+        if dv, ok := v.(demandVertex); ok {
+            demands[v.GetPos()] = dv.GetDemand()
+        }
+        if p := v.GetPos(); p != d {
+            if demands[p] > capacity {
+                return nil, 0, errors.New(fmt.Sprintf("The demand of vertex %d exceeds the capacity.", v.GetId()))
+            }
+            if math.IsInf(weights[d][p], 1) {
+                return nil, 0, errors.New(fmt.Sprintf("Vertex %d is not connected to the depot.", v.GetId()))
+            }
+        }
+    }
+
+    routes := savingsRoutes(weights, demands, d, capacity)
+    routes = improveRoutes(weights, demands, d, capacity, routes)
+
+    // build the result
+    result, total := make([]VehicleRoute, 0, len(routes)), 0.0
+    for _, route := range routes {
+        r := VehicleRoute{
+            Vertices: graph.tourVertices(route),
+            Load: routeLoad(demands, route),
+            Length: routeLength(weights, d, route),
+        }
+        result = append(result, r)
+        total += r.Length
+    }
+    return result, total, nil
+}
+
+// a saving of joining two routes by connecting i and j instead of going back to the depot
+type saving struct {
+    i, j  int
+    value float64
+}
+
+// sorts the savings from the highest to the lowest
+type savingsList []saving
+
+func (this savingsList) Len() int {
+    return len(this)
+}
+
+func (this savingsList) Less(i, j int) bool {
+    return this[i].value > this[j].value
+}
+
+func (this savingsList) Swap(i, j int) {
+    this[i], this[j] = this[j], this[i]
+}
+
+// returns the routes built by the parallel savings algorithm as vertex positions without the depot
+func savingsRoutes(weights [][]float64, demands []float64, depot int, capacity float64) [][]int {
+    num := len(weights)
+
+    /*
+     * 1. Every vertex gets its own route.
+     */
+    routes, routeOf, loads := make([][]int, num), make([]int, num), make([]float64, num)
+    for v := 0; v < num; v++ {
+        if v != depot {
+            routes[v], routeOf[v], loads[v] = []int{v}, v, demands[v]
+        }
+    }
+
+    /*
+     * 2. Calculate the savings.
+     */
+    savings := make(savingsList, 0, num * num / 2)
+    for i := 0; i < num; i++ {
+        for j := i + 1; j < num; j++ {
+            if i == depot || j == depot || math.IsInf(weights[i][j], 1) {
+                continue
+            }
+            if s := weights[i][depot] + weights[depot][j] - weights[i][j]; s > localSearchEpsilon {
+                savings = append(savings, saving{i, j, s})
+            }
+        }
+    }
+    sort.Sort(savings)
+
+    /*
+     * 3. Join the routes of the highest savings if both vertices are at an end of their routes.
+     */
+    for _, s := range savings {
+        a, b := routeOf[s.i], routeOf[s.j]
+        if a == b || loads[a] + loads[b] > capacity {
+            continue
+        }
+        ra, rb := routes[a], routes[b]
+
+        // i has to be the last vertex of its route and j the first one of its route
+        if ra[len(ra) - 1] != s.i {
+            if ra[0] != s.i {
+                continue
+            }
+            reverseInts(ra)
+        }
+        if rb[0] != s.j {
+            if rb[len(rb) - 1] != s.j {
+                continue
+            }
+            reverseInts(rb)
+        }
+
+        routes[a], routes[b], loads[a] = append(ra, rb...), nil, loads[a] + loads[b]
+        for _, v := range rb {
+            routeOf[v] = a
+        }
+    }
+
+    result := make([][]int, 0, num)
+    for _, route := range routes {
+        if len(route) > 0 {
+            result = append(result, route)
+        }
+    }
+    return result
+}
+
+// improves the routes by 2-opt and by exchanging their ends until no improvement is left
+// Returns the improved routes, routes that became empty are removed.
+func improveRoutes(weights [][]float64, demands []float64, depot int, capacity float64, routes [][]int) [][]int {
+    w := weights
+
+    // returns the vertex at position i of the route including the depot at both ends
+    at := func(route []int, i int) int {
+        if i == 0 || i == len(route) + 1 {
+            return depot
+        }
+        return route[i - 1]
+    }
+
+    for improved := true; improved; {
+        improved = false
+
+        // 2-opt within each route: reverse the path from i+1 to j
+        for _, route := range routes {
+            for i := 0; i < len(route); i++ {
+                for j := i + 2; j <= len(route); j++ {
+                    a, b, c, e := at(route, i), at(route, i + 1), at(route, j), at(route, j + 1)
+                    if w[a][b] + w[c][e] - w[a][c] - w[b][e] > localSearchEpsilon {
+                        reverseInts(route[i:j])
+                        improved = true
+                    }
+                }
+            }
+        }
+
+        // exchange the ends of two routes behind position i and j: a -> b and c -> e become a -> e and c -> b
+        for x := range routes {
+            for y := range routes {
+                if x >= y {
+                    continue
+                }
+                rx, ry := routes[x], routes[y]
+                loadX, loadY := prefixLoads(demands, rx), prefixLoads(demands, ry)
+                for i := 0; i <= len(rx) && !improved; i++ {
+                    for j := 0; j <= len(ry); j++ {
+                        if loadX[i] + loadY[len(ry)] - loadY[j] > capacity || loadY[j] + loadX[len(rx)] - loadX[i] > capacity {
+                            continue
+                        }
+                        a, b, c, e := at(rx, i), at(rx, i + 1), at(ry, j), at(ry, j + 1)
+                        if w[a][b] + w[c][e] - w[a][e] - w[c][b] > localSearchEpsilon {
+                            routes[x] = append(append([]int{}, rx[:i]...), ry[j:]...)
+                            routes[y] = append(append([]int{}, ry[:j]...), rx[i:]...)
+                            improved = true
+                            break
+                        }
+                    }
+                }
+            }
+        }
+    }
+
+    // remove routes that became empty
+    result := make([][]int, 0, len(routes))
+    for _, route := range routes {
+        if len(route) > 0 {
+            result = append(result, route)
+        }
+    }
+    return result
+}
+
+// returns the loads of the first i vertices of the route for each i
+func prefixLoads(demands []float64, route []int) []float64 {
+    loads := make([]float64, len(route) + 1)
+    for i, v := range route {
+        loads[i + 1] = loads[i] + demands[v]
+    }
+    return loads
+}
+
+// returns the sum of the demands of the vertices
+func routeLoad(demands []float64, route []int) float64 {
+    load := 0.0
+    for _, v := range route {
+        load += demands[v]
+    }
+    return load
+}
+
+// returns the length of the route that starts and ends at the depot
+func routeLength(weights [][]float64, depot int, route []int) float64 {
+    return tourLength(weights, append([]int{depot}, route...))
+}
@@ -0,0 +1,100 @@
+package algorithm
+
+import (
+    "testing"
+    "strings"
+    "fmt"
+    "math"
+    "math/rand"
+    "github.com/teelevision/fhac-mmi/parser"
+)
+
+// returns a demand file of the complete graph of the points with euclidean weights
+func euclideanDemandInput(points [][2]float64, demands []float64) string {
+    input := fmt.Sprintln(len(points))
+    for _, d := range demands {
+        input += fmt.Sprintln(d)
+    }
+    for i := range points {
+        for j := i + 1; j < len(points); j++ {
+            input += fmt.Sprintln(i, j, math.Hypot(points[i][0] - points[j][0], points[i][1] - points[j][1]))
+        }
+    }
+    return input
+}
+
+// checks that the routes supply every vertex once within the capacity
+func validateRoutes(t *testing.T, graph Graph, depot int, capacity float64, routes []VehicleRoute, total float64) {
+    weights, seen, sum := graph.getWeightMatrix(), make(map[int]bool), 0.0
+    for _, route := range routes {
+        load := 0.0
+        for _, v := range route.Vertices {
+            if v.GetPos() == depot || seen[v.GetPos()] {
+                t.Errorf("Expected vertex %d to be visited once and not to be the depot.", v.GetId())
+            }
+            seen[v.GetPos()] = true
+            load += v.(demandVertex).GetDemand()
+        }
+        if load != route.Load || load > capacity {
+            t.Errorf("Expected a load of %f within the capacity %f, got %f.", load, capacity, route.Load)
+        }
+        if l := routeLength(weights, depot, tourPositions(route.Vertices)); math.Abs(l - route.Length) > 1e-9 {
+            t.Errorf("Expected route length %f, got %f.", l, route.Length)
+        }
+        sum += route.Length
+    }
+    if len(seen) != len(weights) - 1 {
+        t.Errorf("Expected %d vertices to be visited, got %d.", len(weights) - 1, len(seen))
+    }
+    if math.Abs(sum - total) > 1e-9 {
+        t.Errorf("Expected total length %f, got %f.", sum, total)
+    }
+}
+
+// test the savings algorithm on an instance with a known optimum
+func TestVehicleRoutingSavings(t *testing.T) {
+    points := [][2]float64{{0, 0}, {10, 0}, {11, 0}, {-10, 0}, {-11, 0}, {0, 10}}
+    g, err := parser.ParseDemand(strings.NewReader(euclideanDemandInput(points, []float64{0, 1, 1, 1, 1, 2})))
+    if err != nil {
+        panic(err)
+    }
+    g.SetDirected(false)
+    graph := Graph{g}
+    depot := graph.GetVertices().Get(0)
+
+    routes, total, err := graph.VehicleRoutingSavings(depot, 2)
+    if err != nil {
+        t.Fatal(err)
+    }
+    validateRoutes(t, graph, 0, 2, routes, total)
+    if len(routes) != 3 || math.Abs(total - 64) > 1e-9 {
+        t.Errorf("Expected 3 routes of total length 64, got %d of length %f.", len(routes), total)
+    }
+
+    // a demand that no vehicle can carry
+    if _, _, err := graph.VehicleRoutingSavings(depot, 1); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}
+
+// test the savings algorithm on random instances
+func TestVehicleRoutingSavingsRandom(t *testing.T) {
+    r := rand.New(rand.NewSource(1))
+    for n := 0; n < 10; n++ {
+        points, demands := make([][2]float64, 40), make([]float64, 40)
+        for i := range points {
+            points[i], demands[i] = [2]float64{r.Float64() * 100, r.Float64() * 100}, float64(1 + r.Intn(10))
+        }
+        g, err := parser.ParseDemand(strings.NewReader(euclideanDemandInput(points, demands)))
+        if err != nil {
+            panic(err)
+        }
+        g.SetDirected(false)
+        graph := Graph{g}
+        routes, total, err := graph.VehicleRoutingSavings(graph.GetVertices().Get(0), 30)
+        if err != nil {
+            t.Fatal(err)
+        }
+        validateRoutes(t, graph, 0, 30, routes, total)
+    }
+}
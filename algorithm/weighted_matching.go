@@ -0,0 +1,686 @@
+package algorithm
+
+import (
+    "math"
+)
+
+// the tolerance used when comparing slacks and dual variables with zero
+const weightedMatchingEpsilon = 1e-9
+
+// an edge of the graph the weighted matching works on
+type weightedMatchingEdge struct {
+    i, j   int
+    weight float64
+}
+
+// returns a minimum weight perfect matching of the given vertex positions using the weights
+// The result maps each of the positions to its matching partner.
+// The number of vertices has to be even and every vertex has to be connected to each other.
+func minWeightPerfectMatching(weights [][]float64, vertices []int) map[int]int {
+
+    // transform into a maximum weight problem
+    // all weights must be positive, so every maximum cardinality matching is preferred over smaller ones
+    maxWeight := 0.0
+    for a, u := range vertices {
+        for _, v := range vertices[a + 1:] {
+            maxWeight = math.Max(maxWeight, weights[u][v])
+        }
+    }
+    edges := make([]weightedMatchingEdge, 0, len(vertices) * (len(vertices) - 1) / 2)
+    for a, u := range vertices {
+        for b := a + 1; b < len(vertices); b++ {
+            edges = append(edges, weightedMatchingEdge{a, b, maxWeight + 1.0 - weights[u][vertices[b]]})
+        }
+    }
+
+    // map back to positions
+    mate := maxWeightMatching(len(vertices), edges, true)
+    result := make(map[int]int, len(vertices))
+    for a, b := range mate {
+        if b >= 0 {
+            result[vertices[a]] = vertices[b]
+        }
+    }
+    return result
+}
+
+// returns a maximum weight matching using Edmonds' blossom algorithm with dual variables in O(n^3)
+// If maxCardinality is true, only maximum cardinality matchings are considered.
+// The result contains the matching partner of each vertex or -1 if the vertex is not matched.
+func maxWeightMatching(numVertices int, edges []weightedMatchingEdge, maxCardinality bool) []int {
+    if len(edges) == 0 {
+        mate := make([]int, numVertices)
+        for v := range mate {
+            mate[v] = -1
+        }
+        return mate
+    }
+    m := newWeightedMatching(numVertices, edges)
+    m.solve(maxCardinality)
+    return m.result()
+}
+
+// the state of the blossom algorithm
+// Vertices are 0..n-1, blossoms n..2n-1. Edge endpoints are p = 2k and 2k+1 for edge k.
+type weightedMatching struct {
+    n                int
+    edges            []weightedMatchingEdge
+    endpoint         []int
+    neighbend        [][]int
+    mate             []int
+    label            []int
+    labelend         []int
+    inblossom        []int
+    blossomparent    []int
+    blossomchilds    [][]int
+    blossombase      []int
+    blossomendps     [][]int
+    bestedge         []int
+    blossombestedges [][]int
+    unusedblossoms   []int
+    dualvar          []float64
+    allowedge        []bool
+    queue            []int
+}
+
+// inits the state
+func newWeightedMatching(n int, edges []weightedMatchingEdge) *weightedMatching {
+    maxWeight := 0.0
+    for _, e := range edges {
+        maxWeight = math.Max(maxWeight, e.weight)
+    }
+
+    m := &weightedMatching{
+        n: n,
+        edges: edges,
+        endpoint: make([]int, 2 * len(edges)),
+        neighbend: make([][]int, n),
+        mate: make([]int, n),
+        label: make([]int, 2 * n),
+        labelend: make([]int, 2 * n),
+        inblossom: make([]int, n),
+        blossomparent: make([]int, 2 * n),
+        blossomchilds: make([][]int, 2 * n),
+        blossombase: make([]int, 2 * n),
+        blossomendps: make([][]int, 2 * n),
+        bestedge: make([]int, 2 * n),
+        blossombestedges: make([][]int, 2 * n),
+        unusedblossoms: make([]int, 0, n),
+        dualvar: make([]float64, 2 * n),
+        allowedge: make([]bool, len(edges)),
+    }
+    for k, e := range edges {
+        m.endpoint[2 * k], m.endpoint[2 * k + 1] = e.i, e.j
+        m.neighbend[e.i] = append(m.neighbend[e.i], 2 * k + 1)
+        m.neighbend[e.j] = append(m.neighbend[e.j], 2 * k)
+    }
+    for v := 0; v < n; v++ {
+        m.mate[v] = -1
+        m.inblossom[v] = v
+        m.blossombase[v] = v
+        m.blossombase[n + v] = -1
+        m.dualvar[v] = maxWeight
+        m.unusedblossoms = append(m.unusedblossoms, n + v)
+    }
+    for b := 0; b < 2 * n; b++ {
+        m.labelend[b] = -1
+        m.blossomparent[b] = -1
+        m.bestedge[b] = -1
+    }
+    return m
+}
+
+// returns the slack of the edge
+func (this *weightedMatching) slack(k int) float64 {
+    e := this.edges[k]
+    return this.dualvar[e.i] + this.dualvar[e.j] - 2 * e.weight
+}
+
+// returns the vertices of the (nested) blossom
+func (this *weightedMatching) blossomLeaves(b int) []int {
+    if b < this.n {
+        return []int{b}
+    }
+    leaves := make([]int, 0, len(this.blossomchilds[b]))
+    for _, t := range this.blossomchilds[b] {
+        leaves = append(leaves, this.blossomLeaves(t)...)
+    }
+    return leaves
+}
+
+// assigns label t to the top-level blossom containing vertex w that is reached by endpoint p
+func (this *weightedMatching) assignLabel(w, t, p int) {
+    b := this.inblossom[w]
+    this.label[w], this.label[b] = t, t
+    this.labelend[w], this.labelend[b] = p, p
+    this.bestedge[w], this.bestedge[b] = -1, -1
+    if t == 1 {
+        // b became an S-blossom, add its vertices to the queue
+        this.queue = append(this.queue, this.blossomLeaves(b)...)
+    } else if t == 2 {
+        // b became a T-blossom, assign label S to its mate
+        base := this.blossombase[b]
+        this.assignLabel(this.endpoint[this.mate[base]], 1, this.mate[base] ^ 1)
+    }
+}
+
+// traces back from v and w to find a new blossom or an augmenting path
+// Returns the base of the new blossom or -1 for an augmenting path.
+func (this *weightedMatching) scanBlossom(v, w int) int {
+    path := make([]int, 0)
+    base := -1
+    for v != -1 || w != -1 {
+        b := this.inblossom[v]
+        if this.label[b] & 4 != 0 {
+            base = this.blossombase[b]
+            break
+        }
+        path = append(path, b)
+        this.label[b] = 5
+        if this.labelend[b] == -1 {
+            // reached a single vertex, stop
+            v = -1
+        } else {
+            v = this.endpoint[this.labelend[b]]
+            b = this.inblossom[v]
+            v = this.endpoint[this.labelend[b]]
+        }
+        // swap v and w so that we alternate between both paths
+        if w != -1 {
+            v, w = w, v
+        }
+    }
+    for _, b := range path {
+        this.label[b] = 1
+    }
+    return base
+}
+
+// constructs a new blossom with the given base, containing edge k
+func (this *weightedMatching) addBlossom(base, k int) {
+    v, w := this.edges[k].i, this.edges[k].j
+    bb, bv, bw := this.inblossom[base], this.inblossom[v], this.inblossom[w]
+
+    // create blossom
+    b := this.unusedblossoms[len(this.unusedblossoms) - 1]
+    this.unusedblossoms = this.unusedblossoms[:len(this.unusedblossoms) - 1]
+    this.blossombase[b] = base
+    this.blossomparent[b] = -1
+    this.blossomparent[bb] = b
+
+    // trace back from v to base
+    path, endps := make([]int, 0), make([]int, 0)
+    for bv != bb {
+        this.blossomparent[bv] = b
+        path = append(path, bv)
+        endps = append(endps, this.labelend[bv])
+        v = this.endpoint[this.labelend[bv]]
+        bv = this.inblossom[v]
+    }
+    path = append(path, bb)
+    reverseInts(path)
+    reverseInts(endps)
+    endps = append(endps, 2 * k)
+
+    // trace back from w to base
+    for bw != bb {
+        this.blossomparent[bw] = b
+        path = append(path, bw)
+        endps = append(endps, this.labelend[bw] ^ 1)
+        w = this.endpoint[this.labelend[bw]]
+        bw = this.inblossom[w]
+    }
+    this.blossomchilds[b], this.blossomendps[b] = path, endps
+
+    // set label to S
+    this.label[b] = 1
+    this.labelend[b] = this.labelend[bb]
+    this.dualvar[b] = 0
+
+    // relabel vertices
+    for _, v := range this.blossomLeaves(b) {
+        if this.label[this.inblossom[v]] == 2 {
+            // this T-vertex now turns into an S-vertex because it becomes part of an S-blossom
+            this.queue = append(this.queue, v)
+        }
+        this.inblossom[v] = b
+    }
+
+    // compute the least-slack edges to neighbouring S-blossoms
+    bestedgeto := make([]int, 2 * this.n)
+    for i := range bestedgeto {
+        bestedgeto[i] = -1
+    }
+    for _, bv := range path {
+        var nblists [][]int
+        if this.blossombestedges[bv] == nil {
+            for _, v := range this.blossomLeaves(bv) {
+                nblist := make([]int, len(this.neighbend[v]))
+                for i, p := range this.neighbend[v] {
+                    nblist[i] = p / 2
+                }
+                nblists = append(nblists, nblist)
+            }
+        } else {
+            nblists = [][]int{this.blossombestedges[bv]}
+        }
+        for _, nblist := range nblists {
+            for _, k := range nblist {
+                i, j := this.edges[k].i, this.edges[k].j
+                if this.inblossom[j] == b {
+                    i, j = j, i
+                }
+                bj := this.inblossom[j]
+                if bj != b && this.label[bj] == 1 && (bestedgeto[bj] == -1 || this.slack(k) < this.slack(bestedgeto[bj])) {
+                    bestedgeto[bj] = k
+                }
+            }
+        }
+        this.blossombestedges[bv] = nil
+        this.bestedge[bv] = -1
+    }
+    this.blossombestedges[b] = make([]int, 0)
+    for _, k := range bestedgeto {
+        if k != -1 {
+            this.blossombestedges[b] = append(this.blossombestedges[b], k)
+        }
+    }
+
+    // select the best edge
+    this.bestedge[b] = -1
+    for _, k := range this.blossombestedges[b] {
+        if this.bestedge[b] == -1 || this.slack(k) < this.slack(this.bestedge[b]) {
+            this.bestedge[b] = k
+        }
+    }
+}
+
+// expands the given top-level blossom
+func (this *weightedMatching) expandBlossom(b int, endstage bool) {
+
+    // convert sub-blossoms into top-level blossoms
+    for _, s := range this.blossomchilds[b] {
+        this.blossomparent[s] = -1
+        if s < this.n {
+            this.inblossom[s] = s
+        } else if endstage && math.Abs(this.dualvar[s]) <= weightedMatchingEpsilon {
+            // recursively expand this sub-blossom
+            this.expandBlossom(s, endstage)
+        } else {
+            for _, v := range this.blossomLeaves(s) {
+                this.inblossom[v] = s
+            }
+        }
+    }
+
+    // if we expand a T-blossom during a stage, its sub-blossoms must be relabeled
+    if !endstage && this.label[b] == 2 {
+        childs, endps := this.blossomchilds[b], this.blossomendps[b]
+
+        // start at the sub-blossom through which the expanding blossom obtained its label
+        entrychild := this.inblossom[this.endpoint[this.labelend[b] ^ 1]]
+        j := indexOfInt(childs, entrychild)
+
+        // move along the blossom until we get to the base
+        var jstep, endptrick int
+        if j & 1 != 0 {
+            j -= len(childs)
+            jstep, endptrick = 1, 0
+        } else {
+            jstep, endptrick = -1, 1
+        }
+        p := this.labelend[b]
+        for j != 0 {
+            // relabel the T-sub-blossom
+            this.label[this.endpoint[p ^ 1]] = 0
+            this.label[this.endpoint[endps[wrapIndex(j - endptrick, len(endps))] ^ endptrick ^ 1]] = 0
+            this.assignLabel(this.endpoint[p ^ 1], 2, p)
+            // step to the next S-sub-blossom and note its forward endpoint
+            this.allowedge[endps[wrapIndex(j - endptrick, len(endps))] / 2] = true
+            j += jstep
+            p = endps[wrapIndex(j - endptrick, len(endps))] ^ endptrick
+            // step to the next T-sub-blossom
+            this.allowedge[p / 2] = true
+            j += jstep
+        }
+
+        // relabel the base T-sub-blossom without stepping through to its mate
+        bv := childs[wrapIndex(j, len(childs))]
+        this.label[this.endpoint[p ^ 1]], this.label[bv] = 2, 2
+        this.labelend[this.endpoint[p ^ 1]], this.labelend[bv] = p, p
+        this.bestedge[bv] = -1
+
+        // continue along the blossom until we get back to the entry child
+        j += jstep
+        for childs[wrapIndex(j, len(childs))] != entrychild {
+            bv := childs[wrapIndex(j, len(childs))]
+            if this.label[bv] == 1 {
+                // this sub-blossom just got label S through one of its neighbours
+                j += jstep
+                continue
+            }
+            // find a vertex that got a label
+            v, found := -1, false
+            for _, v = range this.blossomLeaves(bv) {
+                if this.label[v] != 0 {
+                    found = true
+                    break
+                }
+            }
+            // if the sub-blossom contains a reachable vertex, assign label T to the sub-blossom
+            if found {
+                this.label[v] = 0
+                this.label[this.endpoint[this.mate[this.blossombase[bv]]]] = 0
+                this.assignLabel(v, 2, this.labelend[v])
+            }
+            j += jstep
+        }
+    }
+
+    // recycle the blossom number
+    this.label[b], this.labelend[b] = -1, -1
+    this.blossomchilds[b], this.blossomendps[b] = nil, nil
+    this.blossombase[b] = -1
+    this.blossombestedges[b] = nil
+    this.bestedge[b] = -1
+    this.unusedblossoms = append(this.unusedblossoms, b)
+}
+
+// swaps matched/unmatched edges over an alternating path through blossom b between vertex v and the base
+func (this *weightedMatching) augmentBlossom(b, v int) {
+
+    // bubble up through the blossom tree from vertex v to an immediate sub-blossom of b
+    t := v
+    for this.blossomparent[t] != b {
+        t = this.blossomparent[t]
+    }
+    if t >= this.n {
+        this.augmentBlossom(t, v)
+    }
+
+    // decide in which direction we will go round the blossom
+    childs, endps := this.blossomchilds[b], this.blossomendps[b]
+    i := indexOfInt(childs, t)
+    j := i
+    var jstep, endptrick int
+    if i & 1 != 0 {
+        j -= len(childs)
+        jstep, endptrick = 1, 0
+    } else {
+        jstep, endptrick = -1, 1
+    }
+
+    // move along the blossom until we get to the base
+    for j != 0 {
+        j += jstep
+        t = childs[wrapIndex(j, len(childs))]
+        p := endps[wrapIndex(j - endptrick, len(endps))] ^ endptrick
+        if t >= this.n {
+            this.augmentBlossom(t, this.endpoint[p])
+        }
+        j += jstep
+        t = childs[wrapIndex(j, len(childs))]
+        if t >= this.n {
+            this.augmentBlossom(t, this.endpoint[p ^ 1])
+        }
+        // match the edge connecting those sub-blossoms
+        this.mate[this.endpoint[p]] = p ^ 1
+        this.mate[this.endpoint[p ^ 1]] = p
+    }
+
+    // rotate the list of sub-blossoms to put the new base at the front
+    this.blossomchilds[b] = append(append(make([]int, 0, len(childs)), childs[i:]...), childs[:i]...)
+    this.blossomendps[b] = append(append(make([]int, 0, len(endps)), endps[i:]...), endps[:i]...)
+    this.blossombase[b] = this.blossombase[this.blossomchilds[b][0]]
+}
+
+// swaps matched/unmatched edges over an alternating path between two single vertices through edge k
+func (this *weightedMatching) augmentMatching(k int) {
+    v, w := this.edges[k].i, this.edges[k].j
+    for _, sp := range [2][2]int{{v, 2 * k + 1}, {w, 2 * k}} {
+        s, p := sp[0], sp[1]
+
+        // match vertex s to remote endpoint p, then trace back from s until we find a single vertex
+        for {
+            bs := this.inblossom[s]
+            if bs >= this.n {
+                this.augmentBlossom(bs, s)
+            }
+            this.mate[s] = p
+            if this.labelend[bs] == -1 {
+                // reached single vertex
+                break
+            }
+            t := this.endpoint[this.labelend[bs]]
+            bt := this.inblossom[t]
+            s = this.endpoint[this.labelend[bt]]
+            j := this.endpoint[this.labelend[bt] ^ 1]
+            if bt >= this.n {
+                this.augmentBlossom(bt, j)
+            }
+            this.mate[j] = this.labelend[bt]
+            p = this.labelend[bt] ^ 1
+        }
+    }
+}
+
+// runs the main loop, one stage per augmentation
+func (this *weightedMatching) solve(maxCardinality bool) {
+    n := this.n
+    for stage := 0; stage < n; stage++ {
+
+        // reset labels
+        for i := range this.label {
+            this.label[i] = 0
+            this.bestedge[i] = -1
+        }
+        for b := n; b < 2 * n; b++ {
+            this.blossombestedges[b] = nil
+        }
+        for k := range this.allowedge {
+            this.allowedge[k] = false
+        }
+        this.queue = this.queue[:0]
+
+        // label single blossoms/vertices with S and put them in the queue
+        for v := 0; v < n; v++ {
+            if this.mate[v] == -1 && this.label[this.inblossom[v]] == 0 {
+                this.assignLabel(v, 1, -1)
+            }
+        }
+
+        augmented := false
+        for {
+
+            // continue labeling until all vertices reachable through an alternating path got a label
+            for len(this.queue) > 0 && !augmented {
+                v := this.queue[len(this.queue) - 1]
+                this.queue = this.queue[:len(this.queue) - 1]
+
+                for _, p := range this.neighbend[v] {
+                    k, w := p / 2, this.endpoint[p]
+                    if this.inblossom[v] == this.inblossom[w] {
+                        // internal edge
+                        continue
+                    }
+                    kslack := 0.0
+                    if !this.allowedge[k] {
+                        kslack = this.slack(k)
+                        if kslack <= weightedMatchingEpsilon {
+                            // edge k has zero slack, so it is allowed
+                            this.allowedge[k] = true
+                        }
+                    }
+                    if this.allowedge[k] {
+                        if this.label[this.inblossom[w]] == 0 {
+                            // w is a free vertex, label it with T and its mate with S
+                            this.assignLabel(w, 2, p ^ 1)
+                        } else if this.label[this.inblossom[w]] == 1 {
+                            // either a new blossom or an augmenting path
+                            if base := this.scanBlossom(v, w); base >= 0 {
+                                this.addBlossom(base, k)
+                            } else {
+                                this.augmentMatching(k)
+                                augmented = true
+                                break
+                            }
+                        } else if this.label[w] == 0 {
+                            // w is inside a T-blossom, but w itself has not yet been reached from outside
+                            this.label[w] = 2
+                            this.labelend[w] = p ^ 1
+                        }
+                    } else if this.label[this.inblossom[w]] == 1 {
+                        // keep track of the least-slack non-allowable edge to a different S-blossom
+                        b := this.inblossom[v]
+                        if this.bestedge[b] == -1 || kslack < this.slack(this.bestedge[b]) {
+                            this.bestedge[b] = k
+                        }
+                    } else if this.label[w] == 0 {
+                        // keep track of the least-slack edge to a free vertex
+                        if this.bestedge[w] == -1 || kslack < this.slack(this.bestedge[w]) {
+                            this.bestedge[w] = k
+                        }
+                    }
+                }
+            }
+            if augmented {
+                break
+            }
+
+            // there is no augmenting path under these constraints, compute delta and update the dual variables
+            deltatype, delta, deltaedge, deltablossom := -1, 0.0, -1, -1
+
+            // 1. the minimum value of any vertex dual
+            if !maxCardinality {
+                deltatype = 1
+                delta = math.Inf(1)
+                for v := 0; v < n; v++ {
+                    delta = math.Min(delta, this.dualvar[v])
+                }
+            }
+
+            // 2. the minimum slack on any edge between an S-vertex and a free vertex
+            for v := 0; v < n; v++ {
+                if this.label[this.inblossom[v]] == 0 && this.bestedge[v] != -1 {
+                    if d := this.slack(this.bestedge[v]); deltatype == -1 || d < delta {
+                        delta, deltatype, deltaedge = d, 2, this.bestedge[v]
+                    }
+                }
+            }
+
+            // 3. half the minimum slack on any edge between a pair of S-blossoms
+            for b := 0; b < 2 * n; b++ {
+                if this.blossomparent[b] == -1 && this.label[b] == 1 && this.bestedge[b] != -1 {
+                    if d := this.slack(this.bestedge[b]) / 2; deltatype == -1 || d < delta {
+                        delta, deltatype, deltaedge = d, 3, this.bestedge[b]
+                    }
+                }
+            }
+
+            // 4. the minimum z variable of any T-blossom
+            for b := n; b < 2 * n; b++ {
+                if this.blossombase[b] >= 0 && this.blossomparent[b] == -1 && this.label[b] == 2 && (deltatype == -1 || this.dualvar[b] < delta) {
+                    delta, deltatype, deltablossom = this.dualvar[b], 4, b
+                }
+            }
+
+            // no further improvement possible, max-cardinality optimum reached
+            if deltatype == -1 {
+                deltatype = 1
+                delta = math.Inf(1)
+                for v := 0; v < n; v++ {
+                    delta = math.Min(delta, this.dualvar[v])
+                }
+                delta = math.Max(0, delta)
+            }
+
+            // update dual variables according to delta
+            for v := 0; v < n; v++ {
+                if this.label[this.inblossom[v]] == 1 {
+                    this.dualvar[v] -= delta
+                } else if this.label[this.inblossom[v]] == 2 {
+                    this.dualvar[v] += delta
+                }
+            }
+            for b := n; b < 2 * n; b++ {
+                if this.blossombase[b] >= 0 && this.blossomparent[b] == -1 {
+                    if this.label[b] == 1 {
+                        this.dualvar[b] += delta
+                    } else if this.label[b] == 2 {
+                        this.dualvar[b] -= delta
+                    }
+                }
+            }
+
+            // take action at the point where minimum delta occurred
+            if deltatype == 1 {
+                // no further improvement possible
+                break
+            } else if deltatype == 2 {
+                // use the least-slack edge to continue the search
+                this.allowedge[deltaedge] = true
+                i, j := this.edges[deltaedge].i, this.edges[deltaedge].j
+                if this.label[this.inblossom[i]] == 0 {
+                    i, j = j, i
+                }
+                this.queue = append(this.queue, i)
+            } else if deltatype == 3 {
+                // use the least-slack edge to continue the search
+                this.allowedge[deltaedge] = true
+                this.queue = append(this.queue, this.edges[deltaedge].i)
+            } else if deltatype == 4 {
+                // expand the least-z blossom
+                this.expandBlossom(deltablossom, false)
+            }
+        }
+
+        // stop when no more augmenting path can be found
+        if !augmented {
+            break
+        }
+
+        // end of stage, expand all S-blossoms which have dualvar = 0
+        for b := n; b < 2 * n; b++ {
+            if this.blossomparent[b] == -1 && this.blossombase[b] >= 0 && this.label[b] == 1 && math.Abs(this.dualvar[b]) <= weightedMatchingEpsilon {
+                this.expandBlossom(b, true)
+            }
+        }
+    }
+}
+
+// returns the matching partner of each vertex or -1
+func (this *weightedMatching) result() []int {
+    mate := make([]int, this.n)
+    for v := range mate {
+        mate[v] = -1
+        if this.mate[v] >= 0 {
+            mate[v] = this.endpoint[this.mate[v]]
+        }
+    }
+    return mate
+}
+
+// reverses the slice in place
+func reverseInts(s []int) {
+    for i, j := 0, len(s) - 1; i < j; i, j = i + 1, j - 1 {
+        s[i], s[j] = s[j], s[i]
+    }
+}
+
+// returns the index of the value in the slice or -1
+func indexOfInt(s []int, value int) int {
+    for i, v := range s {
+        if v == value {
+            return i
+        }
+    }
+    return -1
+}
+
+// maps negative indexes to positions counted from the end like python does
+func wrapIndex(i, length int) int {
+    if i < 0 {
+        return i + length
+    }
+    return i
+}
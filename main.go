@@ -11,27 +11,60 @@ import (
     "os"
     "runtime/pprof"
     "runtime"
+    "math"
+    "strings"
+    "strconv"
 )
 
 var config struct {
     files               []string
     inputFormat         *string
     weights             *bool
+    capacities          *bool
     directed            *bool
     print               *bool
     breadthFirstSearch  *bool
     depthFirstSearch    *bool
     connectedComponents *bool
+    componentsMode      *string
     prim                *bool
     kruskal             *bool
+    boruvka             *bool
+    arborescence        *bool
+    mstSensitivity      *bool
+    biconnectivity      *bool
+    countTrees          *string
+    allMinimalTrees     *int
+    steiner             *string
+    mehlhorn            *bool
     nearestNeighbour    *bool
+    euclidean           *bool
+    insertion           *string
+    seed                *int64
+    annealing           *bool
+    genetic             *bool
+    iterations          *int
+    timeLimit           *time.Duration
     doubleTree          *bool
+    christofides        *bool
+    linKernighan        *bool
+    patching            *bool
+    metricClosure       *bool
+    report              *bool
+    localSearch         *bool
     travelingSalesmanBF *bool
     travelingSalesmanBB *bool
+    workers             *int
+    travelingSalesmanHK *bool
+    heldKarpMemory      *uint
+    travelingSalesmanOT *bool
+    vehicleRouting      *bool
+    vehicleCapacity     *float64
     shortestPath        *string
     maxFlow             *bool
     optimalFlow         *string
     maxMatching         *bool
+    stableMatching      *bool
     startVertex         *int
     endVertex           *int
     showTime            *bool
@@ -40,23 +73,53 @@ var config struct {
 
 // inits the current config
 func initConfig() {
-    config.inputFormat = flag.String("f", "list", "input format (matrix|list|flow|bip)")
+    config.inputFormat = flag.String("f", "list", "input format (matrix|list|flow|bip|pref|demand|coord)")
     config.weights = flag.Bool("w", false, "input list contains weights")
+    config.capacities = flag.Bool("c", false, "bipartite input contains capacities")
     config.directed = flag.Bool("d", false, "graph is directed")
     config.print = flag.Bool("print", true, "print info")
     config.breadthFirstSearch = flag.Bool("breadth", false, "breadth-first search")
     config.depthFirstSearch = flag.Bool("depth", false, "depth-first search")
     config.connectedComponents = flag.Bool("components", false, "connected components")
+    config.componentsMode = flag.String("componentsmode", "", "mode of the connected components (strong|weak = strongly with condensation|weakly, default via searches)")
+    config.biconnectivity = flag.Bool("bicon", false, "articulation points, bridges, biconnected components and the block-cut tree")
     config.prim = flag.Bool("prim", false, "prim minimal spanning tree length")
     config.kruskal = flag.Bool("kruskal", false, "kruskal minimal spanning tree length")
+    config.boruvka = flag.Bool("boruvka", false, "boruvka minimal spanning tree length (parallel, see workers)")
+    config.arborescence = flag.Bool("arb", false, "minimum spanning arborescence rooted at the start vertex (Chu-Liu/Edmonds)")
+    config.mstSensitivity = flag.Bool("mstsens", false, "weight ranges of the edges for which the minimal spanning tree stays minimal and the second-best tree")
+    config.countTrees = flag.String("count", "", "number of spanning trees (exact|log = big integer|natural logarithm)")
+    config.allMinimalTrees = flag.Int("allmst", 0, "number of minimal spanning trees and up to this many of them")
+    config.steiner = flag.String("steiner", "", "steiner tree connecting the comma separated terminal vertices (e.g. 0,4,7)")
+    config.mehlhorn = flag.Bool("mehlhorn", false, "use Mehlhorn's variant of the steiner tree approximation")
     config.nearestNeighbour = flag.Bool("nn", false, "nearest neighbour hamilton circle length")
+    config.euclidean = flag.Bool("euclid", false, "use euclidean distances of the coordinates with a k-d tree for nn, ins (n|r) and ls")
+    config.insertion = flag.String("ins", "", "hamilton circle by insertion (n|c|f|r = nearest|cheapest|farthest|random)")
+    config.seed = flag.Int64("seed", 1, "seed of the random number generator")
+    config.annealing = flag.Bool("sa", false, "hamilton circle by simulated annealing")
+    config.genetic = flag.Bool("ga", false, "hamilton circle by a genetic algorithm")
+    config.iterations = flag.Int("iterations", 0, "maximum iterations of the metaheuristics (0 = default)")
+    config.timeLimit = flag.Duration("timelimit", 0, "maximum time of the metaheuristics (e.g. 10s)")
     config.doubleTree = flag.Bool("dt", false, "double tree hamilton circle length")
+    config.christofides = flag.Bool("chr", false, "christofides hamilton circle length")
+    config.linKernighan = flag.Bool("lk", false, "lin-kernighan hamilton circle length")
+    config.patching = flag.Bool("ap", false, "hamilton circle by assignment and patching (for directed graphs)")
+    config.metricClosure = flag.Bool("mc", false, "search hamilton circles on the metric closure and expand them to walks")
+    config.report = flag.Bool("report", false, "compare the hamilton circles with lower bounds and each other")
+    config.localSearch = flag.Bool("ls", false, "improve hamilton circles by local search (2-opt, Or-opt, 3-opt)")
     config.travelingSalesmanBF = flag.Bool("tsbf", false, "traveling salesman brute force")
     config.travelingSalesmanBB = flag.Bool("tsbb", false, "traveling salesman branch and bound")
+    config.workers = flag.Int("workers", 0, "number of workers of the traveling salesman brute force and boruvka (0 = number of CPUs)")
+    config.travelingSalesmanHK = flag.Bool("tshk", false, "traveling salesman held-karp")
+    config.heldKarpMemory = flag.Uint("hkmem", 4096, "maximum memory of held-karp in MiB")
+    config.travelingSalesmanOT = flag.Bool("ts1t", false, "traveling salesman branch and bound with 1-tree bounds")
+    config.vehicleRouting = flag.Bool("cvrp", false, "capacitated vehicle routing from the start vertex (Clarke-Wright savings)")
+    config.vehicleCapacity = flag.Float64("capacity", 1, "capacity of the vehicles")
     config.shortestPath = flag.String("sp", "", "shortest path (d|mbf)")
     config.maxFlow = flag.Bool("maxflow", false, "maximum flow")
     config.optimalFlow = flag.String("of", "", "optimal flow (cc|ssp)")
     config.maxMatching = flag.Bool("maxmatching", false, "maximum matching")
+    config.stableMatching = flag.Bool("stable", false, "stable matching (Gale-Shapley)")
     config.startVertex = flag.Int("start", 0, "start vertex")
     config.endVertex = flag.Int("end", -1, "end vertex")
     config.showTime = flag.Bool("t", false, "show time")
@@ -84,12 +147,352 @@ func parseFile(file string) (*graphLib.Graph, error) {
     case "flow":
         return parser.ParseFlowFile(file)
     case "bip":
-        return parser.ParseBipartiteFile(file)
+        return parser.ParseBipartiteFile(file, *config.capacities)
+    case "pref":
+        return parser.ParsePreferenceFile(file)
+    case "demand":
+        return parser.ParseDemandFile(file)
+    case "coord":
+        return parser.ParseCoordinatesFile(file)
     default:
         panic(errors.New(fmt.Sprintf("Unkown input format \"%s\".", *config.inputFormat)))
     }
 }
 
+// returns the vertices of the comma separated ids
+func parseVertexList(graph algorithm.Graph, list string) ([]graphLib.VertexInterface, error) {
+    var vertices []graphLib.VertexInterface
+    for _, s := range strings.Split(list, ",") {
+        id, err := strconv.Atoi(strings.TrimSpace(s))
+        if err != nil {
+            return nil, err
+        }
+        if id < 0 || id >= int(graph.GetVertices().Count()) {
+            return nil, errors.New(fmt.Sprintf("Vertex %d does not exist.", id))
+        }
+        vertices = append(vertices, graph.GetVertices().Get(uint(id)))
+    }
+    return vertices, nil
+}
+
+// a hamilton circle of the quality report
+type reportEntry struct {
+    name     string
+    length   float64
+    duration time.Duration
+}
+
+// the hamilton circles of the current file
+type qualityReport struct {
+    entries []reportEntry
+}
+
+// adds the hamilton circle and the time it took to find it
+func (this *qualityReport) add(name string, length float64, duration time.Duration) {
+    this.entries = append(this.entries, reportEntry{name, length, duration})
+}
+
+// returns the relative difference of the length to the reference in percent, or n/a if there is none
+func percentTo(length, reference float64) string {
+    if reference <= 0 || math.IsInf(reference, 1) || math.IsNaN(reference) {
+        return "n/a"
+    }
+    return fmt.Sprintf("%+.2f%%", 100 * (length - reference) / reference)
+}
+
+// prints the lower bounds and compares the hamilton circles with them and the best circle
+func (this *qualityReport) print(graph algorithm.Graph, start graphLib.VertexInterface) {
+    // the coordinates have no edges, the 1-tree bound would need all of them
+    // Bounds that are not available are NaN.
+    var mst, oneTree float64
+    var mstTime, oneTreeTime time.Duration
+    begin := time.Now()
+    if *config.euclidean {
+        var err error
+        if mst, err = graph.EuclideanMinimalSpanningTreeLength(); err != nil {
+            fmt.Println("Lower bound (MST):", err.Error())
+            mst = math.NaN()
+        }
+        mstTime, oneTree = time.Since(begin), math.NaN()
+    } else {
+        mst, _, _ = graph.Prim(start)
+        mstTime, begin = time.Since(begin), time.Now()
+        oneTree = graph.OneTreeLowerBound()
+        oneTreeTime = time.Since(begin)
+    }
+
+    lowerBound, best := mst, math.Inf(1)
+    if oneTree > mst {
+        lowerBound = oneTree
+    }
+    for _, entry := range this.entries {
+        if entry.length >= 0 && !math.IsInf(entry.length, 1) {
+            best = math.Min(best, entry.length)
+        }
+    }
+
+    fmt.Println("Quality report:")
+    if math.IsNaN(mst) {
+        fmt.Printf("  %-40s %14s\n", "Lower bound (MST)", "n/a")
+    } else {
+        fmt.Printf("  %-40s %14.2f %26s %12s\n", "Lower bound (MST)", mst, "", mstTime)
+    }
+    if math.IsNaN(oneTree) {
+        fmt.Printf("  %-40s %14s\n", "Lower bound (1-tree)", "n/a")
+    } else {
+        fmt.Printf("  %-40s %14.2f %26s %12s\n", "Lower bound (1-tree)", oneTree, "", oneTreeTime)
+    }
+    for _, entry := range this.entries {
+        if entry.length < 0 || math.IsInf(entry.length, 1) {
+            fmt.Printf("  %-40s %14s %26s %12s\n", entry.name, "no circle", "", entry.duration)
+            continue
+        }
+        fmt.Printf("  %-40s %14.2f %9s to bound %8s to best %12s\n", entry.name, entry.length,
+            percentTo(entry.length, lowerBound), percentTo(entry.length, best), entry.duration)
+    }
+}
+
+// expands hamilton circles of the metric closure to walks in the original graph, nil if not used
+var expandWalk func([]graphLib.VertexInterface) []graphLib.VertexInterface
+
+// prints the vertices of each component
+func printComponents(graph algorithm.Graph, count int, component []int) {
+    members := make([][]graphLib.VertexInterface, count)
+    for _, v := range graph.GetVertices().All() {
+        members[component[v.GetPos()]] = append(members[component[v.GetPos()]], v)
+    }
+    for c, vertices := range members {
+        fmt.Printf("  Component %d:", c)
+        for _, v := range vertices {
+            fmt.Printf(" %d", v.GetId())
+        }
+        fmt.Println()
+    }
+}
+
+// prints the walk in the original graph if the circle was found on the metric closure
+func printWalk(tour []graphLib.VertexInterface) {
+    if expandWalk == nil {
+        return
+    }
+    fmt.Print("Walk in the original graph: [")
+    for _, v := range expandWalk(tour) {
+        fmt.Print(" ", v.GetId())
+    }
+    fmt.Println(" ]")
+}
+
+// prints the shortest hamilton circle and its length or the error
+func printShortestHamiltonCircle(report *qualityReport, name string, tour []graphLib.VertexInterface, length float64, err error, duration time.Duration) {
+    if err != nil {
+        fmt.Println("Shortest Hamilton circle (" + name + "):", err.Error())
+        return
+    }
+    report.add(name, length, duration)
+    fmt.Print("Length of shortest Hamilton circle (", name, "): ", length, " [")
+    for _, v := range tour {
+        fmt.Print(" ", v.GetId())
+    }
+    fmt.Println(" ]")
+    printWalk(tour)
+}
+
+// shows the hamilton circle and its length
+func showHamiltonCircle(report *qualityReport, name string, tour []graphLib.VertexInterface, length float64, duration time.Duration) {
+    report.add(name, length, duration)
+    fmt.Print("Length of Hamilton circle (", name, "): ", length, " [")
+    for _, v := range tour {
+        fmt.Print(" ", v.GetId())
+    }
+    fmt.Println(" ]")
+    printWalk(tour)
+}
+
+// prints the hamilton circle and its length
+// If enabled, the circle is improved by local search and printed again, the duration then includes both.
+func printHamiltonCircle(report *qualityReport, graph algorithm.Graph, name string, tour []graphLib.VertexInterface, length float64, duration time.Duration) {
+    showHamiltonCircle(report, name, tour, length, duration)
+
+    if *config.localSearch {
+        begin := time.Now()
+        if tour, length, err := graph.ImproveHamiltonCircle(tour); err != nil {
+            fmt.Println("Hamilton circle (" + name + " + local search):", err.Error())
+        } else {
+            showHamiltonCircle(report, name + " + local search", tour, length, duration + time.Since(begin))
+        }
+    }
+}
+
+// prints the hamilton circle with euclidean distances and its length or the error
+// If enabled, the circle is improved by 2-opt and printed again, the duration then includes both.
+func printEuclideanHamiltonCircle(report *qualityReport, graph algorithm.Graph, name string, tour []graphLib.VertexInterface, length float64, err error, duration time.Duration) {
+    if err == nil {
+        showHamiltonCircle(report, name, tour, length, duration)
+        if *config.localSearch {
+            begin := time.Now()
+            tour, length, err = graph.EuclideanImproveHamiltonCircle(tour)
+            name, duration = name + " + 2-opt", duration + time.Since(begin)
+        }
+    }
+    if err != nil {
+        fmt.Println("Hamilton circle (" + name + "):", err.Error())
+    } else if *config.localSearch {
+        showHamiltonCircle(report, name, tour, length, duration)
+    }
+}
+
+// searches and prints the requested hamilton circles
+func printHamiltonCircles(graph algorithm.Graph, start graphLib.VertexInterface) {
+
+    // hamilton circles are searched on the metric closure if requested, so that sparse graphs work as well
+    circleGraph, circleStart := graph, start
+    expandWalk = nil
+    if *config.metricClosure {
+        closure, prev, err := graph.MetricClosure()
+        if err != nil {
+            fmt.Println("Hamilton circles (metric closure):", err.Error())
+            return
+        }
+        circleGraph, circleStart = closure, closure.GetVertices().GetPos(start.GetPos())
+        expandWalk = func(tour []graphLib.VertexInterface) []graphLib.VertexInterface {
+            return graph.ExpandWalk(prev, tour)
+        }
+    }
+    report := &qualityReport{}
+
+    // nearest neighbour and insertion with euclidean distances, the edges are not used
+    if *config.euclidean {
+        if *config.nearestNeighbour {
+            begin := time.Now()
+            tour, length, err := graph.EuclideanNearestNeighbourHamiltonCircle(start)
+            printEuclideanHamiltonCircle(report, graph, "Nearest Neighbour[k-d tree]", tour, length, err, time.Since(begin))
+        }
+        switch *config.insertion {
+        case "n":
+            begin := time.Now()
+            tour, length, err := graph.EuclideanNearestInsertionHamiltonCircle(start)
+            printEuclideanHamiltonCircle(report, graph, "Nearest Insertion[k-d tree]", tour, length, err, time.Since(begin))
+        case "r":
+            begin := time.Now()
+            tour, length, err := graph.EuclideanRandomInsertionHamiltonCircle(start, *config.seed)
+            printEuclideanHamiltonCircle(report, graph, "Random Insertion[k-d tree]", tour, length, err, time.Since(begin))
+        case "c", "f":
+            fmt.Printf("Insertion \"%s\" is not supported with euclidean distances.\n", *config.insertion)
+        }
+    } else {
+
+        // nearest neighbour
+        if *config.nearestNeighbour {
+            begin := time.Now()
+            tour, length := circleGraph.NearestNeighbourHamiltonCircle(circleStart)
+            printHamiltonCircle(report, circleGraph, "Nearest Neighbour", tour, length, time.Since(begin))
+        }
+
+        // insertion
+        switch *config.insertion {
+        case "n":
+            begin := time.Now()
+            tour, length := circleGraph.NearestInsertionHamiltonCircle(circleStart)
+            printHamiltonCircle(report, circleGraph, "Nearest Insertion", tour, length, time.Since(begin))
+        case "c":
+            begin := time.Now()
+            tour, length := circleGraph.CheapestInsertionHamiltonCircle(circleStart)
+            printHamiltonCircle(report, circleGraph, "Cheapest Insertion", tour, length, time.Since(begin))
+        case "f":
+            begin := time.Now()
+            tour, length := circleGraph.FarthestInsertionHamiltonCircle(circleStart)
+            printHamiltonCircle(report, circleGraph, "Farthest Insertion", tour, length, time.Since(begin))
+        case "r":
+            begin := time.Now()
+            tour, length := circleGraph.RandomInsertionHamiltonCircle(circleStart, *config.seed)
+            printHamiltonCircle(report, circleGraph, "Random Insertion", tour, length, time.Since(begin))
+        }
+    }
+
+    // metaheuristics
+    limits := algorithm.MetaheuristicLimits{Seed: *config.seed, Iterations: *config.iterations, TimeLimit: *config.timeLimit}
+    if *config.annealing {
+        begin := time.Now()
+        tour, length := circleGraph.SimulatedAnnealingHamiltonCircle(circleStart, nil, algorithm.AnnealingOptions{MetaheuristicLimits: limits})
+        printHamiltonCircle(report, circleGraph, "Simulated Annealing", tour, length, time.Since(begin))
+    }
+    if *config.genetic {
+        begin := time.Now()
+        tour, length := circleGraph.GeneticHamiltonCircle(circleStart, nil, algorithm.GeneticOptions{MetaheuristicLimits: limits})
+        printHamiltonCircle(report, circleGraph, "Genetic", tour, length, time.Since(begin))
+    }
+
+    // double tree
+    if *config.doubleTree {
+        begin := time.Now()
+        if tour, length, err := circleGraph.DoubleTreeHamiltonCircle(algorithm.Prim, circleStart); err != nil {
+            fmt.Println("Hamilton circle (Double Tree[Prim]):", err.Error())
+        } else {
+            printHamiltonCircle(report, circleGraph, "Double Tree[Prim]", tour, length, time.Since(begin))
+        }
+    }
+
+    // christofides
+    if *config.christofides {
+        begin := time.Now()
+        if tour, length, err := circleGraph.ChristofidesHamiltonCircle(circleStart); err != nil {
+            fmt.Println("Hamilton circle (Christofides):", err.Error())
+        } else {
+            printHamiltonCircle(report, circleGraph, "Christofides", tour, length, time.Since(begin))
+        }
+    }
+
+    // lin-kernighan
+    if *config.linKernighan {
+        begin := time.Now()
+        if tour, length, err := circleGraph.LinKernighanHamiltonCircle(circleStart); err != nil {
+            fmt.Println("Hamilton circle (Lin-Kernighan):", err.Error())
+        } else {
+            printHamiltonCircle(report, circleGraph, "Lin-Kernighan", tour, length, time.Since(begin))
+        }
+    }
+
+    // assignment and patching
+    if *config.patching {
+        begin := time.Now()
+        tour, length := circleGraph.AssignmentPatchingHamiltonCircle(circleStart)
+        printHamiltonCircle(report, circleGraph, "Assignment + Patching", tour, length, time.Since(begin))
+    }
+
+    // traveling salesman brute force
+    if *config.travelingSalesmanBF {
+        begin := time.Now()
+        tour, length, err := circleGraph.TravelingSalesmanBruteForce(false, *config.workers)
+        printShortestHamiltonCircle(report, "brute force", tour, length, err, time.Since(begin))
+    }
+
+    // traveling salesman brute force
+    if *config.travelingSalesmanBB {
+        begin := time.Now()
+        tour, length, err := circleGraph.TravelingSalesmanBruteForce(true, *config.workers)
+        printShortestHamiltonCircle(report, "branch and bound", tour, length, err, time.Since(begin))
+    }
+
+    // traveling salesman held-karp
+    if *config.travelingSalesmanHK {
+        begin := time.Now()
+        tour, length, err := circleGraph.TravelingSalesmanHeldKarp(uint64(*config.heldKarpMemory) << 20)
+        printShortestHamiltonCircle(report, "Held-Karp", tour, length, err, time.Since(begin))
+    }
+
+    // traveling salesman branch and bound with 1-tree bounds
+    if *config.travelingSalesmanOT {
+        begin := time.Now()
+        tour, length, err := circleGraph.TravelingSalesmanOneTree()
+        printShortestHamiltonCircle(report, "1-tree", tour, length, err, time.Since(begin))
+    }
+
+    // compare the hamilton circles
+    if *config.report && len(report.entries) > 0 {
+        report.print(circleGraph, circleStart)
+    }
+}
+
 func main() {
 
     initConfig()
@@ -156,11 +559,54 @@ func main() {
         }
 
         // connected components
-        if *config.connectedComponents {
+        switch {
+        case !*config.connectedComponents:
+        case *config.componentsMode == "":
             numB := algorithm.GetNumConnectedComponents(graph, algorithm.BreadthFirstSearch)
             numD := algorithm.GetNumConnectedComponents(graph, algorithm.DepthFirstSearch)
             fmt.Println("Connected components (via breadth-first search):", numB)
             fmt.Println("Connected components (via depth-first search):", numD)
+        case *config.componentsMode == "strong":
+            condensation, component := graph.Condensation()
+            fmt.Println("Strongly connected components (Tarjan):", condensation.GetVertices().Count())
+            printComponents(graph, int(condensation.GetVertices().Count()), component)
+            fmt.Println("Edges of the condensation:")
+            for _, e := range condensation.GetEdges().All() {
+                fmt.Printf("  %d -> %d: %f\n", e.GetStartVertex().GetId(), e.GetEndVertex().GetId(), e.GetWeight())
+            }
+        case *config.componentsMode == "weak":
+            count, component := graph.WeaklyConnectedComponents()
+            fmt.Println("Weakly connected components:", count)
+            printComponents(graph, count, component)
+        default:
+            fmt.Println("Unknown connected components mode:", *config.componentsMode)
+        }
+
+        // articulation points, bridges and blocks
+        if *config.biconnectivity {
+            if result, err := graph.GetBiconnectivity(); err != nil {
+                fmt.Println("Biconnected components:", err.Error())
+            } else {
+                fmt.Print("Articulation points:")
+                for _, v := range result.ArticulationPoints {
+                    fmt.Printf(" %d", v.GetId())
+                }
+                fmt.Println()
+                fmt.Print("Bridges:")
+                for _, e := range result.Bridges {
+                    fmt.Printf(" %d-%d", e.GetStartVertex().GetId(), e.GetEndVertex().GetId())
+                }
+                fmt.Println()
+                fmt.Println("Biconnected components (Hopcroft-Tarjan):", len(result.Blocks))
+                for b, block := range result.Blocks {
+                    fmt.Printf("  Block %d:", b)
+                    for _, e := range block {
+                        fmt.Printf(" %d-%d", e.GetStartVertex().GetId(), e.GetEndVertex().GetId())
+                    }
+                    fmt.Println()
+                }
+                fmt.Printf("Block-cut tree: %d vertices, %d edges\n", result.BlockCutTree.GetVertices().Count(), result.BlockCutTree.GetEdges().Count())
+            }
         }
 
         // prim
@@ -171,36 +617,121 @@ func main() {
 
         // kruskal
         if *config.kruskal {
-            length := graph.KruskalLength()
-            fmt.Println("Length of minimal spanning tree (Kruskal):", length)
+            length, _, _, components := graph.KruskalForest()
+            if components > 1 {
+                fmt.Printf("Length of minimal spanning forest with %d components (Kruskal): %v\n", components, length)
+            } else {
+                fmt.Println("Length of minimal spanning tree (Kruskal):", length)
+            }
         }
 
-        // nearest neighbour
-        if *config.nearestNeighbour {
-            length := graph.NearestNeighbourHamiltonCircleLength(start)
-            fmt.Println("Length of Hamilton circle (Nearest Neighbour):", length)
+        // boruvka
+        if *config.boruvka {
+            length, _, _, components := graph.BoruvkaForest(*config.workers)
+            if components > 1 {
+                fmt.Printf("Length of minimal spanning forest with %d components (Boruvka): %v\n", components, length)
+            } else {
+                fmt.Println("Length of minimal spanning tree (Boruvka):", length)
+            }
+        }
+
+        // minimum spanning arborescence
+        if *config.arborescence {
+            weight, arcs, err := graph.MinimumArborescence(start)
+            fmt.Println("Minimum spanning arborescence (Chu-Liu/Edmonds):")
+            if err != nil {
+                fmt.Printf("  %s\n", err.Error())
+            } else {
+                for _, arc := range arcs {
+                    fmt.Printf("  %d -> %d: %f\n", arc.Parent.GetId(), arc.Child.GetId(), arc.Edge.GetWeight())
+                }
+                fmt.Printf("  Weight: %f\n", weight)
+            }
+        }
+
+        // sensitivity of the minimal spanning tree
+        if *config.mstSensitivity {
+            fmt.Println("Minimal spanning tree sensitivity:")
+            if result, err := graph.MinimalSpanningTreeSensitivity(start); err != nil {
+                fmt.Printf("  %s\n", err.Error())
+            } else {
+                for _, s := range result {
+                    e := s.Edge
+                    if s.InTree {
+                        fmt.Printf("  %d - %d: %f (tree edge, up to %f)\n", e.GetStartVertex().GetId(), e.GetEndVertex().GetId(), e.GetWeight(), s.Upper)
+                    } else {
+                        fmt.Printf("  %d - %d: %f (down to %f)\n", e.GetStartVertex().GetId(), e.GetEndVertex().GetId(), e.GetWeight(), s.Lower)
+                    }
+                }
+            }
+            if weight, _, err := graph.SecondBestMinimalSpanningTree(start); err != nil {
+                fmt.Println("Second-best spanning tree:", err.Error())
+            } else {
+                fmt.Println("Length of second-best spanning tree:", weight)
+            }
         }
 
-        // double tree
-        if *config.doubleTree {
-            tour, length := graph.DoubleTreeHamiltonCircle(algorithm.Prim, start)
-            fmt.Print("Length of Hamilton circle (Double Tree[Prim]): ", length, " [")
-            for _, v := range tour {
-                fmt.Print(" ", v.GetId())
+        // number of spanning trees
+        switch *config.countTrees {
+        case "":
+        case "exact":
+            fmt.Println("Number of spanning trees:", graph.CountSpanningTrees())
+        case "log":
+            fmt.Println("Natural logarithm of the number of spanning trees:", graph.LogCountSpanningTrees())
+        default:
+            fmt.Println("Unknown counting mode:", *config.countTrees)
+        }
+
+        // all minimal spanning trees
+        if *config.allMinimalTrees > 0 {
+            weight, trees := graph.AllMinimalSpanningTrees(*config.allMinimalTrees)
+            fmt.Printf("Number of minimal spanning trees of length %v: %v\n", weight, graph.CountMinimalSpanningTrees())
+            for i, tree := range trees {
+                fmt.Printf("  Tree %d:", i + 1)
+                for _, e := range tree {
+                    fmt.Printf(" %d-%d", e.GetStartVertex().GetId(), e.GetEndVertex().GetId())
+                }
+                fmt.Println()
             }
-            fmt.Println(" ]")
         }
 
-        // traveling salesman brute force
-        if *config.travelingSalesmanBF {
-            length := graph.TravelingSalesmanBruteForce(false)
-            fmt.Println("Length of shortest Hamilton circle (brute force):", length)
+        // steiner tree
+        if *config.steiner != "" {
+            fmt.Println("Steiner tree:")
+            terminals, err := parseVertexList(graph, *config.steiner)
+            weight, edges := 0.0, []graphLib.EdgeInterface(nil)
+            if err == nil {
+                weight, edges, err = graph.SteinerTree(terminals, *config.mehlhorn)
+            }
+            if err != nil {
+                fmt.Printf("  %s\n", err.Error())
+            } else {
+                for _, e := range edges {
+                    fmt.Printf("  %d - %d: %f\n", e.GetStartVertex().GetId(), e.GetEndVertex().GetId(), e.GetWeight())
+                }
+                fmt.Printf("  Weight: %f\n", weight)
+            }
         }
 
-        // traveling salesman brute force
-        if *config.travelingSalesmanBB {
-            length := graph.TravelingSalesmanBruteForce(true)
-            fmt.Println("Length of shortest Hamilton circle (branch and bound):", length)
+        // hamilton circles
+        printHamiltonCircles(graph, start)
+
+        // capacitated vehicle routing
+        if *config.vehicleRouting {
+            routes, length, err := graph.VehicleRoutingSavings(start, *config.vehicleCapacity)
+            fmt.Println("Vehicle routes (Clarke-Wright savings):")
+            if err != nil {
+                fmt.Printf("  %s\n", err.Error())
+            } else {
+                for i, route := range routes {
+                    fmt.Printf("  %d. load %f, length %f:", i + 1, route.Load, route.Length)
+                    for _, v := range route.Vertices {
+                        fmt.Printf(" %d", v.GetId())
+                    }
+                    fmt.Println()
+                }
+                fmt.Printf("  Total length: %f\n", length)
+            }
         }
 
         // shortest paths
@@ -272,6 +803,36 @@ func main() {
                 fmt.Println("\t", e.GetStartVertex().GetPos(), "->", e.GetEndVertex().GetPos())
             }
             fmt.Println("Number of matching edges:", len(matches))
+
+            // derive vertex cover and independent set from the matching, which fails with capacities
+            if cover, err := graph.MinVertexCover(matches); err != nil {
+                fmt.Println("Minimum vertex cover (König):", err.Error())
+            } else {
+                fmt.Print("Minimum vertex cover (König):")
+                for _, v := range cover {
+                    fmt.Printf(" %d", v.GetPos())
+                }
+                fmt.Println()
+            }
+            if independent, err := graph.MaxIndependentSet(matches); err != nil {
+                fmt.Println("Maximum independent set (König):", err.Error())
+            } else {
+                fmt.Print("Maximum independent set (König):")
+                for _, v := range independent {
+                    fmt.Printf(" %d", v.GetPos())
+                }
+                fmt.Println()
+            }
+        }
+
+        // stable matching
+        if *config.stableMatching {
+            matches := graph.StableMatching()
+            fmt.Println("Stable matching edges (Gale-Shapley):")
+            for _, e := range matches {
+                fmt.Println("\t", e.GetStartVertex().GetPos(), "->", e.GetEndVertex().GetPos())
+            }
+            fmt.Println("Number of matching edges:", len(matches))
         }
 
         if *config.showTime {
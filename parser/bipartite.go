@@ -6,22 +6,24 @@ import (
     "os"
 )
 
-// parses an file containing a bipartite graph
-func ParseBipartiteFile(file string) (*graphLib.Graph, error) {
+// parses an file containing a bipartite graph with or without capacities
+func ParseBipartiteFile(file string, withCapacities bool) (*graphLib.Graph, error) {
     f, _ := os.Open(file)
-    graph, err := ParseBipartite(f)
+    graph, err := ParseBipartite(f, withCapacities)
     return graph, err
 }
 
 type GroupVertex struct {
     graphLib.VertexInterface
-    Group int
+    Group    int
+    Capacity int
 }
 
 func (this GroupVertex) Clone() graphLib.VertexInterface {
     return &GroupVertex{
         VertexInterface: this.VertexInterface.Clone(),
         Group: this.Group,
+        Capacity: this.Capacity,
     }
 }
 
@@ -29,8 +31,15 @@ func (this GroupVertex) GetGroup() int {
     return this.Group
 }
 
-// parses an file containing a bipartite graph
-func ParseBipartite(reader io.Reader) (*graphLib.Graph, error) {
+// returns the number of vertices this vertex may be matched with
+func (this GroupVertex) GetCapacity() int {
+    return this.Capacity
+}
+
+// parses an file containing a bipartite graph with or without capacities
+// The capacities follow the number of vertices in the first group, one for each vertex.
+// Without capacities every vertex has a capacity of 1.
+func ParseBipartite(reader io.Reader, withCapacities bool) (*graphLib.Graph, error) {
 
     // parse vertices
     graph, vertices, scanner, err := parseHeader(reader)
@@ -44,7 +53,18 @@ func ParseBipartite(reader io.Reader) (*graphLib.Graph, error) {
         return graph, err
     }
 
-    // use GroupVertex object which will contain the number of the group
+    // parse capacities
+    capacities := make([]int, len(vertices))
+    for i := range capacities {
+        capacities[i] = 1
+        if withCapacities {
+            if capacities[i], err = parseInt(scanner); err != nil {
+                return graph, err
+            }
+        }
+    }
+
+    // use GroupVertex object which will contain the number of the group and the capacity
     graph = graph.Transform(func(v graphLib.VertexInterface) graphLib.VertexInterface {
         group := 0
         if v.GetPos() >= numFirstGroup {
@@ -53,6 +73,7 @@ func ParseBipartite(reader io.Reader) (*graphLib.Graph, error) {
         return &GroupVertex{
             VertexInterface: v,
             Group: group,
+            Capacity: capacities[v.GetPos()],
         }
     }, nil)
 
@@ -0,0 +1,106 @@
+package parser
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "io"
+    "os"
+    "bufio"
+    "strings"
+    "strconv"
+    "errors"
+)
+
+// parses an file containing the coordinates of the vertices
+func ParseCoordinatesFile(file string) (*graphLib.Graph, error) {
+    f, _ := os.Open(file)
+    graph, err := ParseCoordinates(f)
+    return graph, err
+}
+
+// a vertex that is a point in the plane
+type CoordinateVertex struct {
+    graphLib.VertexInterface
+    X, Y float64
+}
+
+func (this CoordinateVertex) Clone() graphLib.VertexInterface {
+    return &CoordinateVertex{
+        VertexInterface: this.VertexInterface.Clone(),
+        X: this.X,
+        Y: this.Y,
+    }
+}
+
+// returns the coordinates of the vertex
+func (this CoordinateVertex) GetCoordinates() (float64, float64) {
+    return this.X, this.Y
+}
+
+// parses an file containing the coordinates of the vertices, no edges are created
+// The file either contains the number of vertices followed by two coordinates for each vertex or
+// it is a TSPLIB file with a DIMENSION and a NODE_COORD_SECTION, where each line is "id x y".
+func ParseCoordinates(reader io.Reader) (*graphLib.Graph, error) {
+
+    scanner := bufio.NewScanner(reader)
+    scanner.Split(bufio.ScanWords)
+
+    // get number of vertices, either directly or from the TSPLIB header
+    numVertices, tsplib := 0, false
+    if !scanner.Scan() {
+        return graphLib.DirectedGraph(), errors.New("EOF")
+    }
+    if n, err := strconv.Atoi(scanner.Text()); err == nil {
+        numVertices = n
+    } else {
+        tsplib = true
+        for token := scanner.Text(); ; token = scanner.Text() {
+            keyword := strings.TrimSuffix(token, ":")
+            if keyword == "NODE_COORD_SECTION" {
+                break
+            }
+            if keyword == "DIMENSION" {
+                // the colon may be a token on its own
+                if numVertices, err = parseInt(scanner); err != nil && scanner.Text() == ":" {
+                    numVertices, err = parseInt(scanner)
+                }
+                if err != nil {
+                    return graphLib.DirectedGraph(), err
+                }
+            }
+            if !scanner.Scan() {
+                return graphLib.DirectedGraph(), errors.New("NODE_COORD_SECTION not found.")
+            }
+        }
+    }
+
+    // create vertices with their coordinates
+    graph := graphLib.CreateNewGraphWithNumVerticesAndNumEdges(true, uint(numVertices), 0)
+    for v := 0; v < numVertices; v++ {
+
+        // skip the id
+        if tsplib {
+            if _, err := parseInt(scanner); err != nil {
+                return graph, err
+            }
+        }
+
+        x, err := parseFloat(scanner)
+        if err != nil {
+            return graph, err
+        }
+        y, err := parseFloat(scanner)
+        if err != nil {
+            return graph, err
+        }
+
+        graph.NewCustomVertex(func(vertex graphLib.VertexInterface) graphLib.VertexInterface {
+            return &CoordinateVertex{
+                VertexInterface: vertex,
+                X: x,
+                Y: y,
+            }
+        })
+    }
+
+    return graph, nil
+}
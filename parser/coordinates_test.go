@@ -0,0 +1,41 @@
+package parser
+
+import (
+    "testing"
+    "strings"
+)
+
+// test parsing coordinates in both formats
+func TestParseCoordinates(t *testing.T) {
+
+    for name, input := range map[string]string{
+        "plain": "3\n0 0\n3 0\n3 4.5\n",
+        "tsplib": "NAME : test\nTYPE: TSP\nDIMENSION : 3\nEDGE_WEIGHT_TYPE : EUC_2D\nNODE_COORD_SECTION\n1 0 0\n2 3 0\n3 3 4.5\nEOF\n",
+    } {
+        graph, err := ParseCoordinates(strings.NewReader(input))
+        if err != nil {
+            panic(err)
+        }
+
+        // test number of vertices and edges
+        if n := graph.GetVertices().Count(); n != 3 {
+            t.Errorf("Graph (%s) should have 3 vertices, got %d.", name, n)
+        }
+        if n := graph.GetEdges().Count(); n != 0 {
+            t.Errorf("Graph (%s) should have no edges, got %d.", name, n)
+        }
+
+        // test the coordinates of the last vertex
+        if x, y := graph.GetVertices().GetPos(2).(*CoordinateVertex).GetCoordinates(); x != 3 || y != 4.5 {
+            t.Errorf("Expected vertex 2 (%s) at (3, 4.5), got (%f, %f).", name, x, y)
+        }
+    }
+}
+
+// test failing to parse a TSPLIB file without coordinates
+func TestParseCoordinatesError(t *testing.T) {
+
+    if _, err := ParseCoordinates(strings.NewReader("NAME : test\nDIMENSION : 3\nEOF\n")); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}
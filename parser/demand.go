@@ -0,0 +1,89 @@
+package parser
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "io"
+    "os"
+)
+
+// parses an file containing a weighted graph with a demand for each vertex
+func ParseDemandFile(file string) (*graphLib.Graph, error) {
+    f, _ := os.Open(file)
+    graph, err := ParseDemand(f)
+    return graph, err
+}
+
+// a vertex that has to be supplied with some amount of goods
+type DemandVertex struct {
+    graphLib.VertexInterface
+    Demand float64
+}
+
+func (this DemandVertex) Clone() graphLib.VertexInterface {
+    return &DemandVertex{
+        VertexInterface: this.VertexInterface.Clone(),
+        Demand: this.Demand,
+    }
+}
+
+// returns the amount of goods the vertex needs
+func (this DemandVertex) GetDemand() float64 {
+    return this.Demand
+}
+
+// parses an file containing a weighted graph with a demand for each vertex
+// The demands follow the number of vertices, one for each vertex. Then the weighted edges follow.
+func ParseDemand(reader io.Reader) (*graphLib.Graph, error) {
+
+    // parse vertices
+    graph, vertices, scanner, err := parseHeader(reader)
+    if err != nil {
+        return graph, err
+    }
+
+    // parse demands
+    demands := make([]float64, len(vertices))
+    for i := range demands {
+        if demands[i], err = parseFloat(scanner); err != nil {
+            return graph, err
+        }
+    }
+
+    // use DemandVertex object which will contain the demand
+    graph = graph.Transform(func(v graphLib.VertexInterface) graphLib.VertexInterface {
+        return &DemandVertex{
+            VertexInterface: v,
+            Demand: demands[v.GetPos()],
+        }
+    }, nil)
+    vertices = graph.GetVertices().All()
+
+    // create edges
+    for {
+
+        // parse start vertex and test if input is empty
+        start, err := parseInt(scanner)
+        if err != nil && err.Error() == "EOF" {
+            break
+        } else if err != nil {
+            return graph, err
+        }
+
+        // parse end vertex
+        end, err := parseInt(scanner)
+        if err != nil {
+            return graph, err
+        }
+
+        // parse weight
+        weight, err := parseFloat(scanner)
+        if err != nil {
+            return graph, err
+        }
+
+        // create edge
+        graph.NewWeightedEdge(vertices[start], vertices[end], weight)
+    }
+
+    return graph, nil
+}
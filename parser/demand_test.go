@@ -0,0 +1,44 @@
+package parser
+
+import (
+    "testing"
+    "strings"
+)
+
+// test parsing a weighted graph with demands
+func TestParseDemand(t *testing.T) {
+
+    graph, err := ParseDemand(strings.NewReader("3\n0\n2\n1.5\n0 1 4\n1 2 2.5\n0 2 3\n"))
+    if err != nil {
+        panic(err)
+    }
+
+    // test number of vertices and edges
+    if n := graph.GetVertices().Count(); n != 3 {
+        t.Errorf("Graph should have 3 vertices, got %d.", n)
+    }
+    if n := graph.GetEdges().Count(); n != 3 {
+        t.Errorf("Graph should have 3 edges, got %d.", n)
+    }
+
+    // test the demands
+    for pos, expected := range []float64{0, 2, 1.5} {
+        if d := graph.GetVertices().GetPos(pos).(*DemandVertex).GetDemand(); d != expected {
+            t.Errorf("Expected demand %f of vertex %d, got %f.", expected, pos, d)
+        }
+    }
+
+    // test the weight of the second edge
+    edge := graph.GetEdges().All()[1]
+    if s, e := edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos(); s != 1 || e != 2 || edge.GetWeight() != 2.5 {
+        t.Errorf("Expected edge from 1 to 2 with weight 2.5, got from %d to %d with weight %f.", s, e, edge.GetWeight())
+    }
+}
+
+// test failing to parse a file with too few demands
+func TestParseDemandError(t *testing.T) {
+
+    if _, err := ParseDemand(strings.NewReader("3\n1\n2\n")); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}
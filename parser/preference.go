@@ -0,0 +1,130 @@
+package parser
+
+import (
+    graphLib "github.com/teelevision/fhac-mmi/graph"
+    "io"
+    "os"
+)
+
+// parses an file containing a bipartite graph with preferences
+func ParsePreferenceFile(file string) (*graphLib.Graph, error) {
+    f, _ := os.Open(file)
+    graph, err := ParsePreference(f)
+    return graph, err
+}
+
+// an edge that knows how much both of its vertices prefer each other
+// A lower rank means a higher preference.
+type PreferenceEdge struct {
+    graphLib.EdgeInterface
+    StartRank float64
+    EndRank   float64
+}
+
+func (this PreferenceEdge) Clone() graphLib.EdgeInterface {
+    return &PreferenceEdge{
+        EdgeInterface: this.EdgeInterface.Clone(),
+        StartRank: this.StartRank,
+        EndRank: this.EndRank,
+    }
+}
+
+// returns the rank the start vertex gives the end vertex
+func (this PreferenceEdge) GetStartRank() float64 {
+    return this.StartRank
+}
+
+// returns the rank the end vertex gives the start vertex
+func (this PreferenceEdge) GetEndRank() float64 {
+    return this.EndRank
+}
+
+// parses an file containing a bipartite graph with preferences
+// After the number of vertices and the number of vertices in the first group follow
+// the capacities of the vertices of the second group and then the edges.
+// Each edge consists of start, end, the rank of end for start and the rank of start for end.
+func ParsePreference(reader io.Reader) (*graphLib.Graph, error) {
+
+    // parse vertices
+    graph, vertices, scanner, err := parseHeader(reader)
+    if err != nil {
+        return graph, err
+    }
+
+    // parse num of vertices in the first group
+    numFirstGroup, err := parseInt(scanner)
+    if err != nil {
+        return graph, err
+    }
+
+    // parse capacities of the second group
+    capacities := make([]int, len(vertices))
+    for i := range capacities {
+        capacities[i] = 1
+        if i >= numFirstGroup {
+            if capacities[i], err = parseInt(scanner); err != nil {
+                return graph, err
+            }
+        }
+    }
+
+    // use GroupVertex object which will contain the number of the group and the capacity
+    graph = graph.Transform(func(v graphLib.VertexInterface) graphLib.VertexInterface {
+        group := 0
+        if v.GetPos() >= numFirstGroup {
+            group = 1
+        }
+        return &GroupVertex{
+            VertexInterface: v,
+            Group: group,
+            Capacity: capacities[v.GetPos()],
+        }
+    }, nil)
+
+    // create edges
+    startRanks, endRanks := make([]float64, 0), make([]float64, 0)
+    for {
+
+        // parse start vertex and test if input is empty
+        start, err := parseInt(scanner)
+        if err != nil && err.Error() == "EOF" {
+            break
+        } else if err != nil {
+            return graph, err
+        }
+
+        // parse end vertex
+        end, err := parseInt(scanner)
+        if err != nil {
+            return graph, err
+        }
+
+        // parse rank of the end vertex
+        startRank, err := parseFloat(scanner)
+        if err != nil {
+            return graph, err
+        }
+        startRanks = append(startRanks, startRank)
+
+        // parse rank of the start vertex
+        endRank, err := parseFloat(scanner)
+        if err != nil {
+            return graph, err
+        }
+        endRanks = append(endRanks, endRank)
+
+        // create edge
+        graph.NewWeightedEdge(vertices[start], vertices[end], 1.0)
+    }
+
+    // add ranks to edges
+    graph = graph.Transform(nil, func(edge graphLib.EdgeInterface) graphLib.EdgeInterface {
+        return &PreferenceEdge{
+            EdgeInterface: edge,
+            StartRank: startRanks[edge.GetPos()],
+            EndRank: endRanks[edge.GetPos()],
+        }
+    })
+
+    return graph, nil
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+    "testing"
+    "strings"
+)
+
+// test parsing a bipartite graph with capacities and preferences
+func TestParsePreference(t *testing.T) {
+
+    graph, err := ParsePreference(strings.NewReader("4\n2\n2\n1\n0 2 1 2\n0 3 2 1\n1 2 2 1\n"))
+    if err != nil {
+        panic(err)
+    }
+
+    // test number of vertices and edges
+    if n := graph.GetVertices().Count(); n != 4 {
+        t.Errorf("Graph should have 4 vertices, got %d.", n)
+    }
+    if n := graph.GetEdges().Count(); n != 3 {
+        t.Errorf("Graph should have 3 edges, got %d.", n)
+    }
+
+    // test the groups and capacities
+    for pos, expected := range []GroupVertex{{Group: 0, Capacity: 1}, {Group: 0, Capacity: 1}, {Group: 1, Capacity: 2}, {Group: 1, Capacity: 1}} {
+        v := graph.GetVertices().GetPos(pos).(*GroupVertex)
+        if v.Group != expected.Group || v.Capacity != expected.Capacity {
+            t.Errorf("Expected vertex %d in group %d with capacity %d, got group %d with capacity %d.", pos, expected.Group, expected.Capacity, v.Group, v.Capacity)
+        }
+    }
+
+    // test the ranks of the second edge
+    edge := graph.GetEdges().All()[1].(*PreferenceEdge)
+    if s, e := edge.GetStartVertex().GetPos(), edge.GetEndVertex().GetPos(); s != 0 || e != 3 {
+        t.Errorf("Expected edge from 0 to 3, got from %d to %d.", s, e)
+    }
+    if edge.GetStartRank() != 2 || edge.GetEndRank() != 1 {
+        t.Errorf("Expected ranks 2 and 1, got %f and %f.", edge.GetStartRank(), edge.GetEndRank())
+    }
+}
+
+// test failing to parse an edge without the rank of its start vertex
+func TestParsePreferenceError(t *testing.T) {
+
+    if _, err := ParsePreference(strings.NewReader("2\n1\n1\n0 1 1\n")); err == nil {
+        t.Error("Expected error, got nil.")
+    }
+}